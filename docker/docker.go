@@ -0,0 +1,130 @@
+// Package docker creates the Docker API client and interprets daemon
+// endpoints given with --host or $DOCKER_HOST.
+package docker
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/docker/cli/cli/connhelper"
+	"github.com/docker/docker/client"
+)
+
+// NewClient creates a Docker client configured from the environment, with
+// host overriding $DOCKER_HOST when set. TLS is set up from
+// $DOCKER_CERT_PATH and $DOCKER_TLS_VERIFY as the docker CLI does, and
+// ssh:// endpoints are reached through ssh as with `docker -H`.
+func NewClient(host string) (Client, error) {
+	helper, err := connhelper.GetConnectionHelper(Endpoint(host))
+	if err != nil {
+		return nil, err
+	}
+	if helper != nil {
+		// The API runs over `docker system dial-stdio` on the remote
+		// host, so there is no TLS to set up
+		cli, err := client.NewClientWithOpts(
+			client.WithHost(helper.Host),
+			client.WithDialContext(helper.Dialer),
+			client.WithVersionFromEnv(),
+			client.WithAPIVersionNegotiation(),
+		)
+		if err != nil {
+			return nil, err
+		}
+		return cli, nil
+	}
+	tlsOpt, err := tlsFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	var opts []client.Opt
+	// The TLS option replaces the HTTP client, so it must come before the
+	// host options that configure its transport
+	if tlsOpt != nil {
+		opts = append(opts, tlsOpt)
+	}
+	opts = append(opts, client.WithHostFromEnv(), client.WithVersionFromEnv(), client.WithAPIVersionNegotiation())
+	if host != "" {
+		opts = append(opts, client.WithHost(host))
+	}
+	cli, err := client.NewClientWithOpts(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return cli, nil
+}
+
+// Endpoint returns the daemon endpoint a client for host connects to.
+func Endpoint(host string) string {
+	if host != "" {
+		return host
+	}
+	return os.Getenv(client.EnvOverrideHost)
+}
+
+// NormalizeHost turns a --host value into a daemon URL. A bare absolute
+// path is taken as a Unix socket, which must exist. ssh://[user@]host[:port]
+// runs the docker CLI on that host, which must be installed there.
+func NormalizeHost(host string) (string, error) {
+	if strings.HasPrefix(host, "/") {
+		host = "unix://" + host
+	}
+	u, err := client.ParseHostURL(host)
+	if err != nil {
+		return "", err
+	}
+	switch u.Scheme {
+	case "unix":
+		// ParseHostURL keeps the socket path in Host
+		fi, err := os.Stat(u.Host)
+		if err != nil {
+			return "", err
+		}
+		if fi.Mode()&os.ModeSocket == 0 {
+			return "", fmt.Errorf("%s is not a socket", u.Host)
+		}
+	case "ssh":
+		if _, err := connhelper.GetConnectionHelper(host); err != nil {
+			return "", err
+		}
+	case "npipe", "tcp", "http", "https":
+	default:
+		return "", fmt.Errorf("unsupported scheme %q in %s (want unix, npipe, tcp, http, https or ssh)", u.Scheme, host)
+	}
+	return host, nil
+}
+
+// RemoteEndpoint returns host when it names a daemon on another machine,
+// or "" for local sockets, pipes and loopback addresses.
+func RemoteEndpoint(host string) string {
+	if host == "" {
+		return ""
+	}
+	u, err := url.Parse(host)
+	if err != nil {
+		return host
+	}
+	switch u.Scheme {
+	case "unix", "npipe":
+		return ""
+	case "tcp", "http", "https":
+		name := u.Hostname()
+		if name == "localhost" {
+			return ""
+		}
+		if ip := net.ParseIP(name); ip != nil && ip.IsLoopback() {
+			return ""
+		}
+	}
+	return host
+}
+
+// IsConnectionError reports whether err means the daemon could not be
+// reached at all, e.g. because it is restarting, rather than that it
+// refused the request.
+func IsConnectionError(err error) bool {
+	return client.IsErrConnectionFailed(err)
+}
@@ -0,0 +1,145 @@
+// Package dockertest provides a fake docker.Client serving canned data, so
+// the dashboard can be tested without a daemon.
+package dockertest
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/Antityping/superdocker/docker"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/docker/docker/api/types/volume"
+)
+
+// Fake is a docker.Client returning the lists it holds. Calls listed in
+// Errs fail with that error instead. Methods it doesn't implement fall
+// through to the nil embedded Client and panic, so a test notices when
+// the code under test reaches further than the fake.
+type Fake struct {
+	docker.Client
+
+	Containers []container.Summary
+	Images     []image.Summary
+	Volumes    []*volume.Volume
+	Networks   []network.Summary
+	Services   []swarm.Service
+
+	// Errs makes the named methods fail, e.g. {"ImageList": err}
+	Errs map[string]error
+
+	mu      sync.Mutex
+	calls   []string
+	streams []context.Context
+}
+
+// Calls returns the methods called so far, with the ID they acted on for
+// container actions, e.g. "ContainerStop abc123", in the order they ran.
+func (f *Fake) Calls() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.calls...)
+}
+
+// LogStreams returns the contexts of the log streams opened so far.
+func (f *Fake) LogStreams() []context.Context {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]context.Context(nil), f.streams...)
+}
+
+// call records a call and returns the error configured for method.
+func (f *Fake) call(method, id string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if id != "" {
+		f.calls = append(f.calls, method+" "+id)
+	} else {
+		f.calls = append(f.calls, method)
+	}
+	return f.Errs[method]
+}
+
+func (f *Fake) ContainerList(context.Context, container.ListOptions) ([]container.Summary, error) {
+	if err := f.call("ContainerList", ""); err != nil {
+		return nil, err
+	}
+	return f.Containers, nil
+}
+
+func (f *Fake) ImageList(context.Context, image.ListOptions) ([]image.Summary, error) {
+	if err := f.call("ImageList", ""); err != nil {
+		return nil, err
+	}
+	return f.Images, nil
+}
+
+func (f *Fake) VolumeList(context.Context, volume.ListOptions) (volume.ListResponse, error) {
+	if err := f.call("VolumeList", ""); err != nil {
+		return volume.ListResponse{}, err
+	}
+	return volume.ListResponse{Volumes: f.Volumes}, nil
+}
+
+func (f *Fake) NetworkList(context.Context, network.ListOptions) ([]network.Summary, error) {
+	if err := f.call("NetworkList", ""); err != nil {
+		return nil, err
+	}
+	return f.Networks, nil
+}
+
+func (f *Fake) ServiceList(context.Context, swarm.ServiceListOptions) ([]swarm.Service, error) {
+	if err := f.call("ServiceList", ""); err != nil {
+		return nil, err
+	}
+	return f.Services, nil
+}
+
+func (f *Fake) ContainerStart(_ context.Context, id string, _ container.StartOptions) error {
+	return f.call("ContainerStart", id)
+}
+
+func (f *Fake) ContainerStop(_ context.Context, id string, _ container.StopOptions) error {
+	return f.call("ContainerStop", id)
+}
+
+func (f *Fake) ContainerRestart(_ context.Context, id string, _ container.StopOptions) error {
+	return f.call("ContainerRestart", id)
+}
+
+func (f *Fake) ContainerRemove(_ context.Context, id string, _ container.RemoveOptions) error {
+	return f.call("ContainerRemove", id)
+}
+
+// ContainerLogs returns no output. A followed stream stays open until ctx
+// is canceled, like the daemon's for a quiet container.
+func (f *Fake) ContainerLogs(ctx context.Context, id string, opts container.LogsOptions) (io.ReadCloser, error) {
+	if err := f.call("ContainerLogs", id); err != nil {
+		return nil, err
+	}
+	if !opts.Follow {
+		return io.NopCloser(strings.NewReader("")), nil
+	}
+	f.mu.Lock()
+	f.streams = append(f.streams, ctx)
+	f.mu.Unlock()
+	return io.NopCloser(ctxReader{ctx}), nil
+}
+
+// ctxReader blocks reads until its context is canceled.
+type ctxReader struct {
+	ctx context.Context
+}
+
+func (r ctxReader) Read([]byte) (int, error) {
+	<-r.ctx.Done()
+	return 0, r.ctx.Err()
+}
+
+func (f *Fake) Close() error {
+	return nil
+}
@@ -0,0 +1,149 @@
+package docker
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/client"
+	"github.com/docker/go-connections/tlsconfig"
+)
+
+// certDir returns the directory holding the TLS client certificates and
+// whether the daemon's certificate is verified, read from the environment
+// like the docker CLI does: $DOCKER_CERT_PATH, defaulting to ~/.docker when
+// only $DOCKER_TLS_VERIFY is set. An empty dir means no TLS.
+func certDir() (dir string, verify bool) {
+	verify = os.Getenv(client.EnvTLSVerify) != ""
+	dir = os.Getenv(client.EnvOverrideCertPath)
+	if dir == "" && verify {
+		if home, err := os.UserHomeDir(); err == nil {
+			dir = filepath.Join(home, ".docker")
+		}
+	}
+	return dir, verify
+}
+
+// tlsFromEnv returns the client option setting up TLS from the environment,
+// or nil when TLS is not configured. Missing, unreadable and expired
+// certificates are reported here, naming the file, rather than as a failed
+// handshake later.
+func tlsFromEnv() (client.Opt, error) {
+	dir, verify := certDir()
+	if dir == "" {
+		return nil, nil
+	}
+	ca := filepath.Join(dir, "ca.pem")
+	cert := filepath.Join(dir, "cert.pem")
+	key := filepath.Join(dir, "key.pem")
+
+	opts := tlsconfig.Options{InsecureSkipVerify: !verify, ExclusiveRootPools: true}
+	if verify {
+		if err := checkFile(ca, dir); err != nil {
+			return nil, err
+		}
+		opts.CAFile = ca
+	}
+	// The client certificate is optional unless the daemon asks for one,
+	// but a half-present pair is always a mistake
+	_, certErr := os.Stat(cert)
+	_, keyErr := os.Stat(key)
+	if certErr == nil || keyErr == nil {
+		if err := checkFile(cert, dir); err != nil {
+			return nil, err
+		}
+		if err := checkFile(key, dir); err != nil {
+			return nil, err
+		}
+		if err := checkCertValidity(cert, time.Now()); err != nil {
+			return nil, err
+		}
+		opts.CertFile, opts.KeyFile = cert, key
+	}
+
+	config, err := tlsconfig.Client(opts)
+	if err != nil {
+		return nil, fmt.Errorf("TLS: %w", err)
+	}
+	return client.WithHTTPClient(&http.Client{
+		Transport:     &http.Transport{TLSClientConfig: config},
+		CheckRedirect: client.CheckRedirect,
+	}), nil
+}
+
+// checkFile reports a missing or unreadable certificate file, pointing at
+// the variable that chose its directory.
+func checkFile(path, dir string) error {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("TLS: %s not found in %s (set %s to the directory holding ca.pem, cert.pem and key.pem)",
+			filepath.Base(path), dir, client.EnvOverrideCertPath)
+	}
+	if err != nil {
+		return fmt.Errorf("TLS: %w", err)
+	}
+	return f.Close()
+}
+
+// checkCertValidity reports a client certificate outside its validity
+// period at now.
+func checkCertValidity(path string, now time.Time) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("TLS: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return fmt.Errorf("TLS: %s is not a PEM certificate", path)
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("TLS: %s: %w", path, err)
+	}
+	switch {
+	case now.After(cert.NotAfter):
+		return fmt.Errorf("TLS: client certificate %s expired on %s", path, cert.NotAfter.UTC().Format("2006-01-02"))
+	case now.Before(cert.NotBefore):
+		return fmt.Errorf("TLS: client certificate %s is not valid until %s", path, cert.NotBefore.UTC().Format("2006-01-02"))
+	}
+	return nil
+}
+
+// ExplainError adds a hint to connection errors caused by TLS problems,
+// which the daemon and net/http report in terms that rarely name the fix.
+// Other errors are returned unchanged.
+func ExplainError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var unknownCA x509.UnknownAuthorityError
+	var invalid x509.CertificateInvalidError
+	var hostname x509.HostnameError
+	msg := err.Error()
+	hint := ""
+	switch {
+	case errors.As(err, &unknownCA):
+		hint = "the daemon's certificate is not signed by ca.pem in " + client.EnvOverrideCertPath
+	case errors.As(err, &invalid) && invalid.Reason == x509.Expired:
+		hint = "the daemon's certificate has expired"
+	case errors.As(err, &hostname):
+		hint = "the daemon's certificate is not valid for the host in --host or " + client.EnvOverrideHost
+	case strings.Contains(msg, "tls: bad certificate"), strings.Contains(msg, "tls: certificate required"):
+		hint = "the daemon rejected the client certificate; check cert.pem and key.pem in " + client.EnvOverrideCertPath
+	case strings.Contains(msg, "tls: expired certificate"):
+		hint = "the daemon rejected the client certificate as expired"
+	case strings.Contains(msg, "sent an HTTP request to an HTTPS server"):
+		hint = "the daemon requires TLS; set " + client.EnvTLSVerify + "=1 and " + client.EnvOverrideCertPath
+	case strings.Contains(msg, "server gave HTTP response to HTTPS client"):
+		hint = "the daemon does not use TLS; unset " + client.EnvTLSVerify + " and " + client.EnvOverrideCertPath
+	default:
+		return err
+	}
+	return fmt.Errorf("%w (%s)", err, hint)
+}
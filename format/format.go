@@ -0,0 +1,90 @@
+// Package format holds the string helpers the dashboard uses to render
+// Docker IDs, sizes and key/value data.
+package format
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Short12 returns the 12-character short form of an ID.
+func Short12(id string) string {
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
+
+// StripSha256 removes the "sha256:" prefix from an ID if present.
+func StripSha256(id string) string {
+	if strings.HasPrefix(id, "sha256:") {
+		return id[len("sha256:"):]
+	}
+	return id
+}
+
+// TrimTo trims s to at most n characters, ending in "..." when cut.
+func TrimTo(s string, n int) string {
+	if n <= 3 || len(s) <= n {
+		return s
+	}
+	return s[:n-3] + "..."
+}
+
+// OrDash returns "-" for empty or whitespace-only strings.
+func OrDash(s string) string {
+	if strings.TrimSpace(s) == "" {
+		return "-"
+	}
+	return s
+}
+
+// QuoteArgs renders an argv as a quoted array, e.g. ["sh", "-c", "echo hi"].
+func QuoteArgs(args []string) string {
+	if len(args) == 0 {
+		return "-"
+	}
+	quoted := make([]string, len(args))
+	for i, a := range args {
+		quoted[i] = strconv.Quote(a)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+// HumanizeBytes formats a byte count using binary units, e.g. 12.3MB.
+func HumanizeBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// JoinKV joins a map as k=v, comma separated, or returns "-" if empty.
+func JoinKV(m map[string]string) string {
+	if len(m) == 0 {
+		return "-"
+	}
+	pairs := make([]string, 0, len(m))
+	for k, v := range m {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", k, v))
+	}
+	return strings.Join(pairs, ", ")
+}
+
+// SortedKV returns a map as sorted "k=v" entries.
+func SortedKV(m map[string]string) []string {
+	out := make([]string, 0, len(m))
+	for k, v := range m {
+		out = append(out, fmt.Sprintf("%s=%s", k, v))
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,90 @@
+package format
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestShort12(t *testing.T) {
+	tests := []struct {
+		id, want string
+	}{
+		{"", ""},
+		{"abc", "abc"},
+		{"0123456789ab", "0123456789ab"},
+		{"0123456789abc", "0123456789ab"},
+		{"0123456789abcdef0123456789abcdef", "0123456789ab"},
+	}
+	for _, tt := range tests {
+		if got := Short12(tt.id); got != tt.want {
+			t.Errorf("Short12(%q) = %q; want %q", tt.id, got, tt.want)
+		}
+	}
+}
+
+func TestStripSha256(t *testing.T) {
+	tests := []struct {
+		id, want string
+	}{
+		{"", ""},
+		{"sha256:", ""},
+		{"sha256:abc", "abc"},
+		{"abc", "abc"},
+		{"SHA256:abc", "SHA256:abc"},
+		{"xsha256:abc", "xsha256:abc"},
+	}
+	for _, tt := range tests {
+		if got := StripSha256(tt.id); got != tt.want {
+			t.Errorf("StripSha256(%q) = %q; want %q", tt.id, got, tt.want)
+		}
+	}
+}
+
+func TestTrimTo(t *testing.T) {
+	tests := []struct {
+		s    string
+		n    int
+		want string
+	}{
+		{"hello world", -1, "hello world"},
+		{"hello world", 0, "hello world"},
+		{"hello world", 3, "hello world"},
+		{"hello world", 4, "h..."},
+		{"hello world", 8, "hello..."},
+		{"hello world", 10, "hello w..."},
+		{"hello world", 11, "hello world"},
+		{"hello world", 12, "hello world"},
+		{"", 5, ""},
+		{"abcd", 4, "abcd"},
+		{"abcde", 4, "a..."},
+	}
+	for _, tt := range tests {
+		if got := TrimTo(tt.s, tt.n); got != tt.want {
+			t.Errorf("TrimTo(%q, %d) = %q; want %q", tt.s, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestJoinKV(t *testing.T) {
+	tests := []struct {
+		name string
+		m    map[string]string
+		want []string // pairs in any order
+	}{
+		{"nil", nil, []string{"-"}},
+		{"empty", map[string]string{}, []string{"-"}},
+		{"one", map[string]string{"a": "1"}, []string{"a=1"}},
+		{"empty value", map[string]string{"a": ""}, []string{"a="}},
+		{"several", map[string]string{"b": "2", "a": "1", "c": "3"}, []string{"a=1", "b=2", "c=3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := strings.Split(JoinKV(tt.m), ", ")
+			slices.Sort(got)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("JoinKV(%v) = %q; want the pairs %q", tt.m, JoinKV(tt.m), tt.want)
+			}
+		})
+	}
+}
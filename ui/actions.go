@@ -0,0 +1,150 @@
+package ui
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/Antityping/superdocker/docker"
+	"github.com/Antityping/superdocker/format"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/docker/docker/api/types/container"
+	imagetypes "github.com/docker/docker/api/types/image"
+	networktypes "github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/pkg/jsonmessage"
+)
+
+// actionDoneMsg reports the outcome of a mutating action. On success the
+// dashboard shows status and reloads.
+type actionDoneMsg struct {
+	id     string // full ID of the resource acted on, if any
+	action string
+	status string
+	reload resourceSet // lists the action changed; zero reloads all
+	err    error
+}
+
+// drainJSONStream reads a daemon JSON message stream to the end, returning
+// the first error reported in it.
+func drainJSONStream(r io.Reader) error {
+	dec := json.NewDecoder(r)
+	for {
+		var jm jsonmessage.JSONMessage
+		if err := dec.Decode(&jm); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if jm.Error != nil {
+			return jm.Error
+		}
+	}
+}
+
+// confirmRecreate asks before recreating the selected container.
+func (m model) confirmRecreate() (tea.Model, tea.Cmd) {
+	c := m.selectedContainer()
+	if c == nil {
+		m.status = "No container selected."
+		return m, nil
+	}
+	name := containerName(*c)
+	return m.askConfirm(
+		fmt.Sprintf("Recreate %s? The current container will be removed.", name),
+		confirmChoice{key: "y", label: "recreate", status: fmt.Sprintf("Recreating %s...", name), id: c.ID, cmd: m.backend.recreateContainer(c.ID, false)},
+		confirmChoice{key: "p", label: "pull & recreate", status: fmt.Sprintf("Pulling image and recreating %s...", name), id: c.ID, cmd: m.backend.recreateContainer(c.ID, true)},
+	)
+}
+
+// recreateContainer replaces a container with a new one built from the same
+// config, optionally pulling its image first, and starts it.
+func (b *backend) recreateContainer(id string, pull bool) tea.Cmd {
+	return b.run(func(ctx context.Context, cli docker.Client) tea.Msg {
+		done := actionDoneMsg{id: id, action: "Recreate", reload: resContainers}
+		if pull {
+			done.reload |= resImages
+		}
+
+		start := time.Now()
+		info, err := cli.ContainerInspect(ctx, id)
+		logCall("ContainerInspect", start, err, "id", format.Short12(id))
+		if err != nil {
+			done.err = err
+			return done
+		}
+		if info.Config == nil {
+			done.err = fmt.Errorf("container %s has no config", format.Short12(id))
+			return done
+		}
+		name := strings.TrimPrefix(info.Name, "/")
+
+		if pull {
+			start = time.Now()
+			rc, err := cli.ImagePull(ctx, info.Config.Image, imagetypes.PullOptions{})
+			logCall("ImagePull", start, err, "image", info.Config.Image)
+			if err != nil {
+				done.err = err
+				return done
+			}
+			err = drainJSONStream(rc)
+			rc.Close()
+			if err != nil {
+				done.err = err
+				return done
+			}
+		}
+
+		// The default hostname is the old container's short ID; let the
+		// daemon assign a fresh one rather than carrying it over
+		cfg := *info.Config
+		if cfg.Hostname == format.Short12(info.ID) {
+			cfg.Hostname = ""
+		}
+
+		// Keep only the user-supplied endpoint settings; the rest is
+		// operational data owned by the old container
+		var netCfg *networktypes.NetworkingConfig
+		if info.NetworkSettings != nil && len(info.NetworkSettings.Networks) > 0 {
+			netCfg = &networktypes.NetworkingConfig{EndpointsConfig: map[string]*networktypes.EndpointSettings{}}
+			for netName, ep := range info.NetworkSettings.Networks {
+				if ep == nil {
+					continue
+				}
+				netCfg.EndpointsConfig[netName] = &networktypes.EndpointSettings{
+					IPAMConfig: ep.IPAMConfig,
+					Links:      ep.Links,
+					Aliases:    ep.Aliases,
+					DriverOpts: ep.DriverOpts,
+					GwPriority: ep.GwPriority,
+				}
+			}
+		}
+
+		start = time.Now()
+		err = cli.ContainerRemove(ctx, id, container.RemoveOptions{Force: true})
+		logCall("ContainerRemove", start, err, "id", format.Short12(id))
+		if err != nil {
+			done.err = err
+			return done
+		}
+		start = time.Now()
+		created, err := cli.ContainerCreate(ctx, &cfg, info.HostConfig, netCfg, nil, name)
+		logCall("ContainerCreate", start, err)
+		if err != nil {
+			done.err = fmt.Errorf("container removed but create failed: %w", err)
+			return done
+		}
+		start = time.Now()
+		err = cli.ContainerStart(ctx, created.ID, container.StartOptions{})
+		logCall("ContainerStart", start, err, "id", format.Short12(created.ID))
+		if err != nil {
+			done.err = fmt.Errorf("container created but start failed: %w", err)
+			return done
+		}
+		done.status = fmt.Sprintf("Recreated %s (%s).", name, format.Short12(created.ID))
+		return done
+	})
+}
@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Resources younger than recentAge count as recent, older than staleAge as
+// stale; anything between is shown plainly.
+const (
+	recentAge = time.Hour
+	staleAge  = 30 * 24 * time.Hour
+)
+
+// The Age column marks recent and stale resources with a character, since
+// cells are measured as plain text and can't carry color; the info panel
+// colors the creation time instead.
+const (
+	recentAgeMarker = "●"
+	staleAgeMarker  = "○"
+)
+
+var (
+	recentAgeStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
+	staleAgeStyle  = lipgloss.NewStyle().Faint(true)
+)
+
+// ageCell renders the Age column for a resource created at t, e.g.
+// "● 12m ago" for a recent one, or "-" when the time is unknown.
+func ageCell(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	d := time.Since(t)
+	switch {
+	case d < recentAge:
+		return recentAgeMarker + " " + agoString(d)
+	case d > staleAge:
+		return staleAgeMarker + " " + agoString(d)
+	}
+	return "  " + agoString(d)
+}
+
+// unixTime converts a list endpoint's Unix timestamp, giving the zero time
+// when it is unset.
+func unixTime(sec int64) time.Time {
+	if sec <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(sec, 0)
+}
+
+// parseCreated parses an RFC 3339 creation time as volumes report it,
+// giving the zero time when it can't be read.
+func parseCreated(s string) time.Time {
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// renderCreated renders a creation time for the info panel in the chosen
+// format, green while recent and dimmed once stale.
+func (f timeFormat) renderCreated(t time.Time) string {
+	s := f.format(t)
+	if t.IsZero() {
+		return s
+	}
+	switch d := time.Since(t); {
+	case d < recentAge:
+		return recentAgeStyle.Render(s)
+	case d > staleAge:
+		return staleAgeStyle.Render(s)
+	}
+	return s
+}
@@ -0,0 +1,261 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/Antityping/superdocker/format"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/term"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/muesli/cancelreader"
+)
+
+// Keys ending an attach session, like docker attach's defaults. They are
+// caught here rather than by the daemon so they also work when the
+// container's stdin isn't open.
+const (
+	detachKey1   = 0x10 // ctrl-p
+	detachKey2   = 0x11 // ctrl-q
+	interruptKey = 0x03 // ctrl-c
+)
+
+type attachDoneMsg struct {
+	name     string
+	detached bool // by the detach keys rather than the process exiting
+	err      error
+}
+
+// attachSession connects the terminal to a container's main process while
+// the dashboard is suspended, like `docker attach`. It implements
+// tea.ExecCommand.
+type attachSession struct {
+	b        *backend
+	id, name string
+	detached bool
+
+	stdin          io.Reader
+	stdout, stderr io.Writer
+}
+
+func (s *attachSession) SetStdin(r io.Reader)  { s.stdin = r }
+func (s *attachSession) SetStdout(w io.Writer) { s.stdout = w }
+func (s *attachSession) SetStderr(w io.Writer) { s.stderr = w }
+
+// fdFile is a terminal's stdin or stdout, as bubbletea passes them.
+type fdFile interface {
+	Fd() uintptr
+}
+
+// Run streams the process's output to the terminal and the keyboard to
+// its stdin until the detach keys are pressed or the process exits.
+func (s *attachSession) Run() error {
+	b := s.b
+	b.mu.Lock()
+	if b.stopped {
+		b.mu.Unlock()
+		return errors.New("shutting down")
+	}
+	b.running.Add(1)
+	cli := b.cli
+	b.mu.Unlock()
+	defer b.running.Done()
+	ctx := b.ctx
+
+	start := time.Now()
+	info, err := cli.ContainerInspect(ctx, s.id)
+	logCall("ContainerInspect", start, err, "id", format.Short12(s.id))
+	if err != nil {
+		return err
+	}
+	if info.State == nil || !info.State.Running {
+		return fmt.Errorf("%s is not running", s.name)
+	}
+	tty := info.Config != nil && info.Config.Tty
+	withStdin := info.Config != nil && info.Config.OpenStdin
+
+	start = time.Now()
+	resp, err := cli.ContainerAttach(ctx, s.id, container.AttachOptions{
+		Stream: true,
+		Stdin:  withStdin,
+		Stdout: true,
+		Stderr: true,
+	})
+	logCall("ContainerAttach", start, err, "id", format.Short12(s.id))
+	if err != nil {
+		return err
+	}
+	defer resp.Close()
+
+	// Raw mode passes ctrl-c and the detach keys through as bytes, so
+	// they reach the input loop instead of signalling superdocker
+	stdout, stderr := s.stdout, s.stderr
+	if f, ok := s.stdin.(fdFile); ok && term.IsTerminal(f.Fd()) {
+		if state, err := term.MakeRaw(f.Fd()); err == nil {
+			defer term.Restore(f.Fd(), state)
+			if !tty {
+				// Without a TTY the process writes bare newlines
+				stdout, stderr = crlfWriter{stdout}, crlfWriter{stderr}
+			}
+		}
+	}
+	fmt.Fprintf(s.stderr, "%s\r\n", attachBanner(s.name, withStdin))
+	if f, ok := s.stdout.(fdFile); ok && tty {
+		if w, h, err := term.GetSize(f.Fd()); err == nil {
+			start := time.Now()
+			err := cli.ContainerResize(ctx, s.id, container.ResizeOptions{Height: uint(h), Width: uint(w)})
+			logCall("ContainerResize", start, err, "id", format.Short12(s.id))
+		}
+	}
+
+	in, err := cancelreader.NewReader(s.stdin)
+	if err != nil {
+		return err
+	}
+	// Stop reading before the dashboard takes the terminal back
+	defer in.Cancel()
+	detach := make(chan struct{})
+	go func() {
+		forwardAttachInput(in, resp.Conn, withStdin, detach)
+		if withStdin {
+			resp.CloseWrite()
+		}
+	}()
+
+	output := make(chan error, 1)
+	go func() {
+		var err error
+		if tty {
+			_, err = io.Copy(stdout, resp.Reader)
+		} else {
+			_, err = stdcopy.StdCopy(stdout, stderr, resp.Reader)
+		}
+		output <- err
+	}()
+
+	select {
+	case err := <-output:
+		return err
+	case <-detach:
+		s.detached = true
+		resp.Close()
+		<-output
+		return nil
+	}
+}
+
+// attachBanner is printed above the process's output, saying how to get
+// back to the dashboard without stopping the container.
+func attachBanner(name string, withStdin bool) string {
+	if !withStdin {
+		return fmt.Sprintf("Attached to %s (output only, stdin is not open). ctrl-p ctrl-q or ctrl-c detaches.", name)
+	}
+	return fmt.Sprintf("Attached to %s. ctrl-p ctrl-q detaches; ctrl-c and exit go to the process and may stop the container.", name)
+}
+
+// forwardAttachInput copies keystrokes from r to the container until r
+// ends or the detach keys are pressed, closing detach then. A lone ctrl-p
+// is held back until the next key shows it wasn't the detach sequence.
+// Without stdin nothing is sent and ctrl-c detaches too.
+func forwardAttachInput(r io.Reader, w io.Writer, withStdin bool, detach chan<- struct{}) {
+	buf := make([]byte, 256)
+	held := false
+	for {
+		n, err := r.Read(buf)
+		out := make([]byte, 0, n+1)
+		detached := false
+		for _, c := range buf[:n] {
+			if held {
+				held = false
+				if c == detachKey2 {
+					detached = true
+					break
+				}
+				out = append(out, detachKey1)
+			}
+			if c == detachKey1 {
+				held = true
+				continue
+			}
+			if c == interruptKey && !withStdin {
+				detached = true
+				break
+			}
+			out = append(out, c)
+		}
+		if withStdin && len(out) > 0 {
+			if _, err := w.Write(out); err != nil {
+				return
+			}
+		}
+		if detached {
+			close(detach)
+			return
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// crlfWriter turns bare newlines into CRLF for a terminal in raw mode.
+type crlfWriter struct {
+	w io.Writer
+}
+
+func (c crlfWriter) Write(p []byte) (int, error) {
+	if _, err := io.WriteString(c.w, strings.ReplaceAll(string(p), "\n", "\r\n")); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// confirmAttach asks before attaching to the selected container, since
+// what's typed there reaches its main process.
+func (m model) confirmAttach() (tea.Model, tea.Cmd) {
+	c := m.selectedContainer()
+	if c == nil {
+		m.status = "No container selected."
+		return m, nil
+	}
+	name := containerName(*c)
+	if c.State != "running" {
+		m.status = fmt.Sprintf("%s is not running.", name)
+		return m, nil
+	}
+	return m.askConfirm(
+		fmt.Sprintf("Attach to %s's main process? ctrl-p ctrl-q detaches and leaves it running; ctrl-c or exit there can stop the container.", name),
+		confirmChoice{
+			key:    "y",
+			label:  "attach to " + name,
+			status: fmt.Sprintf("Attaching to %s...", name),
+			cmd:    m.backend.attach(c.ID, name),
+		},
+	)
+}
+
+// attach suspends the dashboard for an attach session with a container.
+func (b *backend) attach(id, name string) tea.Cmd {
+	s := &attachSession{b: b, id: id, name: name}
+	return tea.Exec(s, func(err error) tea.Msg {
+		return attachDoneMsg{name: name, detached: s.detached, err: err}
+	})
+}
+
+// applyAttachDone reports how an attach session ended and reloads the
+// containers, whose state may have changed.
+func (m model) applyAttachDone(msg attachDoneMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case msg.err != nil:
+		m.status = fmt.Sprintf("Attach to %s failed: %v", msg.name, msg.err)
+	case msg.detached:
+		m.status = fmt.Sprintf("Detached from %s.", msg.name)
+	default:
+		m.status = fmt.Sprintf("%s's output ended; the process may have exited.", msg.name)
+	}
+	return m, m.backend.load(resContainers)
+}
@@ -0,0 +1,96 @@
+package ui
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Antityping/superdocker/docker"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/docker/docker/api/types/filters"
+)
+
+// shutdownTimeout bounds how long teardown waits for in-flight commands
+// after the root context is canceled.
+const shutdownTimeout = 2 * time.Second
+
+// backend owns the Docker client shared by all background commands and the
+// root context they derive from. Quitting cancels the context, waits for
+// running commands to return and only then closes the client.
+type backend struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	host   string // as given to newBackend, for reconnecting
+
+	mu      sync.Mutex
+	cli     docker.Client // replaced by reconnect, guarded by mu
+	stopped bool
+	running sync.WaitGroup
+
+	// filters the list calls send, by table, guarded by mu
+	listFilters [len(tableNames)]filters.Args
+}
+
+// newBackend creates the shared Docker client for host, "" meaning
+// $DOCKER_HOST, and the root context.
+func newBackend(host string) (*backend, error) {
+	cli, err := docker.NewClient(host)
+	if err != nil {
+		debugLog.Error("docker client setup failed", "err", err)
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &backend{ctx: ctx, cancel: cancel, host: host, cli: cli}, nil
+}
+
+// run returns a command calling fn with the root context and client. The
+// command is tracked so teardown can wait for it, and does nothing once the
+// backend is stopped.
+func (b *backend) run(fn func(ctx context.Context, cli docker.Client) tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		b.mu.Lock()
+		if b.stopped {
+			b.mu.Unlock()
+			return nil
+		}
+		b.running.Add(1)
+		cli := b.cli
+		b.mu.Unlock()
+		defer b.running.Done()
+		return fn(b.ctx, cli)
+	}
+}
+
+// stop cancels the root context, aborting in-flight API calls and streams.
+// It is safe to call more than once.
+func (b *backend) stop() {
+	b.mu.Lock()
+	b.stopped = true
+	b.mu.Unlock()
+	b.cancel()
+}
+
+// close stops the backend, waits up to shutdownTimeout for running commands
+// to return and closes the Docker client last.
+func (b *backend) close() error {
+	b.stop()
+	done := make(chan struct{})
+	go func() {
+		b.running.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(shutdownTimeout):
+		debugLog.Warn("background commands still running at shutdown", "timeout", shutdownTimeout)
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.cli.Close()
+}
+
+// quit cancels all background work and exits the program.
+func (m model) quit() (tea.Model, tea.Cmd) {
+	m.backend.stop()
+	return m, tea.Quit
+}
@@ -0,0 +1,112 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Antityping/superdocker/docker"
+	"github.com/Antityping/superdocker/format"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/docker/docker/api/types/container"
+)
+
+// bulkConcurrency caps how many containers a bulk action handles at once.
+const bulkConcurrency = 4
+
+// stoppedStates are the container states start-all starts.
+var stoppedStates = map[string]bool{"created": true, "exited": true}
+
+// confirmBulk asks whether to stop every running container or start every
+// stopped one.
+func (m model) confirmBulk() (tea.Model, tea.Cmd) {
+	var running, stopped []string
+	for _, c := range m.containers {
+		switch {
+		case c.State == "running":
+			running = append(running, c.ID)
+		case stoppedStates[c.State]:
+			stopped = append(stopped, c.ID)
+		}
+	}
+	var choices []confirmChoice
+	if len(running) > 0 {
+		choices = append(choices, confirmChoice{
+			key: "s", label: fmt.Sprintf("stop %d running", len(running)),
+			status: fmt.Sprintf("Stopping %d containers...", len(running)),
+			cmd:    m.backend.bulkContainerAction("Stop all", "stopped", running, stopContainer),
+		})
+	}
+	if len(stopped) > 0 {
+		choices = append(choices, confirmChoice{
+			key: "u", label: fmt.Sprintf("start %d stopped", len(stopped)),
+			status: fmt.Sprintf("Starting %d containers...", len(stopped)),
+			cmd:    m.backend.bulkContainerAction("Start all", "started", stopped, startContainer),
+		})
+	}
+	if len(choices) == 0 {
+		m.status = "No containers to stop or start."
+		return m, nil
+	}
+	return m.askPick("Stop or start all containers?", choices...)
+}
+
+func stopContainer(ctx context.Context, cli docker.Client, id string) error {
+	start := time.Now()
+	err := cli.ContainerStop(ctx, id, container.StopOptions{})
+	logCall("ContainerStop", start, err, "id", format.Short12(id))
+	return err
+}
+
+func startContainer(ctx context.Context, cli docker.Client, id string) error {
+	start := time.Now()
+	err := cli.ContainerStart(ctx, id, container.StartOptions{})
+	logCall("ContainerStart", start, err, "id", format.Short12(id))
+	return err
+}
+
+func restartContainer(ctx context.Context, cli docker.Client, id string) error {
+	start := time.Now()
+	err := cli.ContainerRestart(ctx, id, container.StopOptions{})
+	logCall("ContainerRestart", start, err, "id", format.Short12(id))
+	return err
+}
+
+// bulkContainerAction runs fn for every container in ids, at most
+// bulkConcurrency at a time, and reports how many succeeded and failed.
+// past describes a success in the summary, e.g. "stopped".
+func (b *backend) bulkContainerAction(action, past string, ids []string, fn func(context.Context, docker.Client, string) error) tea.Cmd {
+	return b.run(func(ctx context.Context, cli docker.Client) tea.Msg {
+		done := actionDoneMsg{action: action, reload: resContainers}
+
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		var firstErr error
+		failed := 0
+		sem := make(chan struct{}, bulkConcurrency)
+		for _, id := range ids {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(id string) {
+				defer wg.Done()
+				defer func() { <-sem }()
+				if err := fn(ctx, cli, id); err != nil {
+					mu.Lock()
+					failed++
+					if firstErr == nil {
+						firstErr = fmt.Errorf("%s: %w", format.Short12(id), err)
+					}
+					mu.Unlock()
+				}
+			}(id)
+		}
+		wg.Wait()
+
+		done.status = fmt.Sprintf("%d %s, %d failed.", len(ids)-failed, past, failed)
+		if firstErr != nil {
+			done.status = fmt.Sprintf("%d %s, %d failed (first error: %v).", len(ids)-failed, past, failed, firstErr)
+		}
+		return done
+	})
+}
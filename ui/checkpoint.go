@@ -0,0 +1,307 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Antityping/superdocker/docker"
+	"github.com/Antityping/superdocker/format"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/docker/docker/api/types/checkpoint"
+	"github.com/docker/docker/api/types/container"
+)
+
+// maxPickChoices is how many options a question picking one of several
+// offers, one per digit key.
+const maxPickChoices = 9
+
+type checkpointsLoadedMsg struct {
+	id   string
+	list []checkpoint.Summary
+	err  error
+}
+
+// checkpointsSupported reports whether the daemon runs with experimental
+// features, which the checkpoint API requires. CRIU itself is only checked
+// by the daemon when a checkpoint is taken, so its errors explain a
+// missing install.
+func (m model) checkpointsSupported() bool {
+	return m.hostInfo != nil && m.hostInfo.ExperimentalBuild
+}
+
+// listCheckpoints fetches the checkpoints of a container, like
+// `docker checkpoint ls`.
+func (b *backend) listCheckpoints(id string) tea.Cmd {
+	return b.run(func(ctx context.Context, cli docker.Client) tea.Msg {
+		start := time.Now()
+		list, err := cli.CheckpointList(ctx, id, checkpoint.ListOptions{})
+		logCall("CheckpointList", start, err, "id", format.Short12(id))
+		return checkpointsLoadedMsg{id: id, list: list, err: err}
+	})
+}
+
+// checkpointsFor returns a command fetching the checkpoints of a container
+// for the info panel, or nil when unsupported, cached or in flight.
+func (m *model) checkpointsFor(id string) tea.Cmd {
+	if !m.checkpointsSupported() {
+		return nil
+	}
+	if _, ok := m.checkpoints[id]; ok {
+		return nil
+	}
+	m.checkpoints[id] = nil
+	return m.backend.listCheckpoints(id)
+}
+
+// applyCheckpoints caches a fetched checkpoint list. A failed fetch is
+// cached too, as an empty list, so the panel doesn't retry on every
+// selection; the status says why.
+func (m model) applyCheckpoints(msg checkpointsLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.checkpoints[msg.id] = []checkpoint.Summary{}
+		m.status = fmt.Sprintf("Checkpoints unavailable: %v", docker.ExplainError(msg.err))
+		return m, nil
+	}
+	list := msg.list
+	if list == nil {
+		list = []checkpoint.Summary{}
+	}
+	m.checkpoints[msg.id] = list
+	return m, nil
+}
+
+// renderCheckpoints renders the cached checkpoints of a container for the
+// info panel.
+func (m model) renderCheckpoints(id string) string {
+	list, ok := m.checkpoints[id]
+	switch {
+	case !ok || list == nil:
+		return "Checkpoints: loading..."
+	case len(list) == 0:
+		return "Checkpoints: - (m: Checkpoint)"
+	}
+	lines := make([]string, 0, len(list)+1)
+	lines = append(lines, fmt.Sprintf("Checkpoints: %d", len(list)))
+	for _, cp := range list {
+		lines = append(lines, "  "+cp.Name)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// checkpointMenuItems lists the checkpoint actions for c, or none when the
+// daemon doesn't support checkpoints.
+func (m model) checkpointMenuItems(c container.Summary) []menuItem {
+	if !m.checkpointsSupported() {
+		return nil
+	}
+	notRunning := ""
+	if c.State != "running" {
+		notRunning = "not running"
+	}
+	none := ""
+	switch list, ok := m.checkpoints[c.ID]; {
+	case !ok || list == nil:
+		none = "checkpoints loading"
+	case len(list) == 0:
+		none = "no checkpoints"
+	}
+	restore := none
+	if restore == "" && !stoppedStates[c.State] {
+		restore = "not stopped"
+	}
+	return []menuItem{
+		{label: "Checkpoint", mutates: true, disabled: notRunning, run: model.openCheckpointPrompt},
+		{label: "Restore checkpoint", mutates: true, disabled: restore, run: model.pickRestoreCheckpoint},
+		{label: "Delete checkpoint", mutates: true, disabled: none, run: model.pickDeleteCheckpoint},
+	}
+}
+
+// openCheckpointPrompt asks for the name of a new checkpoint of the
+// selected container, prefilled with a timestamped one so the daemon's
+// alphabetical listing is also chronological.
+func (m model) openCheckpointPrompt() (tea.Model, tea.Cmd) {
+	c := m.selectedContainer()
+	if c == nil {
+		m.status = "No container selected."
+		return m, nil
+	}
+	m.checkpointID = c.ID
+	name := "cp-" + time.Now().Format("20060102-150405")
+	return m.openPrompt(promptCheckpoint, fmt.Sprintf("Checkpoint %s as: ", containerName(*c)), name)
+}
+
+// checkpointTarget returns the name of the container the checkpoint prompt
+// applies to.
+func (m model) checkpointTarget() string {
+	if i, ok := m.index[0][m.checkpointID]; ok && i < len(m.containers) {
+		return containerName(m.containers[i])
+	}
+	return format.Short12(m.checkpointID)
+}
+
+// parseCheckpointName checks a typed checkpoint name against the daemon's
+// naming rule, which is the one for container names, and the container's
+// existing checkpoints.
+func parseCheckpointName(s string, existing []checkpoint.Summary) (string, error) {
+	name := strings.TrimSpace(s)
+	switch {
+	case name == "":
+		return "", errors.New("enter a name")
+	case len(name) < 2:
+		return "", errors.New("names need at least 2 characters")
+	case !isAlnum(name[0]):
+		return "", errors.New("names must start with a letter or digit")
+	case !containerNamePattern.MatchString(name):
+		return "", errors.New("names may only contain letters, digits, '_', '.' and '-'")
+	case slices.ContainsFunc(existing, func(cp checkpoint.Summary) bool { return cp.Name == name }):
+		return "", fmt.Errorf("a checkpoint named %s already exists", name)
+	}
+	return name, nil
+}
+
+// checkpointPreview renders the line under the checkpoint prompt: the
+// checkpoint to be taken, or the rule the input breaks.
+func (m model) checkpointPreview() string {
+	name, err := parseCheckpointName(m.input.Value(), m.checkpoints[m.checkpointID])
+	if err != nil {
+		return exitErrorStyle.Render("  ✗ " + err.Error())
+	}
+	return helpStyle.Render(fmt.Sprintf("  checkpoint %s as %s   enter: continue • esc: cancel", m.checkpointTarget(), name))
+}
+
+// confirmCheckpoint asks whether the container stops once checkpointed,
+// as `docker checkpoint create` does by default, or keeps running.
+func (m model) confirmCheckpoint(value string) (tea.Model, tea.Cmd) {
+	name, err := parseCheckpointName(value, m.checkpoints[m.checkpointID])
+	if err != nil {
+		m.status = fmt.Sprintf("No checkpoint taken: %v", err)
+		return m, nil
+	}
+	id, target := m.checkpointID, m.checkpointTarget()
+	status := fmt.Sprintf("Checkpointing %s as %s...", target, name)
+	return m.askConfirm(fmt.Sprintf("Checkpoint %s as %s?", target, name),
+		confirmChoice{
+			key: "y", label: "checkpoint and stop", status: status, id: id,
+			cmd: m.backend.createCheckpoint(id, target, name, true),
+		},
+		confirmChoice{
+			key: "l", label: "leave running", status: status, id: id,
+			cmd: m.backend.createCheckpoint(id, target, name, false),
+		},
+	)
+}
+
+// pickRestoreCheckpoint asks which checkpoint to start the selected
+// container from.
+func (m model) pickRestoreCheckpoint() (tea.Model, tea.Cmd) {
+	return m.pickCheckpoint("Restore %s from which checkpoint?", "Restore %s from checkpoint %s?",
+		func(id, target, name string) confirmChoice {
+			return confirmChoice{
+				label:  "restore",
+				status: fmt.Sprintf("Restoring %s from %s...", target, name),
+				id:     id,
+				cmd:    m.backend.restoreCheckpoint(id, target, name),
+			}
+		})
+}
+
+// pickDeleteCheckpoint asks which checkpoint of the selected container to
+// delete.
+func (m model) pickDeleteCheckpoint() (tea.Model, tea.Cmd) {
+	return m.pickCheckpoint("Delete which checkpoint of %s?", "Delete checkpoint %[2]s of %[1]s?",
+		func(id, target, name string) confirmChoice {
+			return confirmChoice{
+				label:  "delete",
+				status: fmt.Sprintf("Deleting checkpoint %s of %s...", name, target),
+				id:     id,
+				cmd:    m.backend.deleteCheckpoint(id, target, name),
+			}
+		})
+}
+
+// pickCheckpoint asks for one of the selected container's checkpoints,
+// keyed 1-9, and runs the choice built for it. With a single checkpoint it
+// asks the one question instead, which no-confirm mode skips. Picking
+// among several is never skipped, since there is no safe default.
+func (m model) pickCheckpoint(which, one string, choice func(id, target, name string) confirmChoice) (tea.Model, tea.Cmd) {
+	c := m.selectedContainer()
+	if c == nil {
+		m.status = "No container selected."
+		return m, nil
+	}
+	list := m.checkpoints[c.ID]
+	target := containerName(*c)
+	switch len(list) {
+	case 0:
+		m.status = fmt.Sprintf("%s has no checkpoints.", target)
+		return m, nil
+	case 1:
+		ch := choice(c.ID, target, list[0].Name)
+		ch.key = "y"
+		return m.askConfirm(fmt.Sprintf(one, target, list[0].Name), ch)
+	}
+	choices := make([]confirmChoice, 0, maxPickChoices)
+	for i, cp := range list[:min(len(list), maxPickChoices)] {
+		ch := choice(c.ID, target, cp.Name)
+		ch.key, ch.label = fmt.Sprint(i+1), cp.Name
+		choices = append(choices, ch)
+	}
+	return m.askPick(fmt.Sprintf(which, target), choices...)
+}
+
+// createCheckpoint checkpoints a running container, like
+// `docker checkpoint create`, stopping it afterwards when exit is set.
+func (b *backend) createCheckpoint(id, target, name string, exit bool) tea.Cmd {
+	return b.run(func(ctx context.Context, cli docker.Client) tea.Msg {
+		done := actionDoneMsg{id: id, action: "Checkpoint", reload: resContainers}
+		start := time.Now()
+		err := cli.CheckpointCreate(ctx, id, checkpoint.CreateOptions{CheckpointID: name, Exit: exit})
+		logCall("CheckpointCreate", start, err, "id", format.Short12(id), "checkpoint", name, "exit", exit)
+		if err != nil {
+			done.err = err
+			return done
+		}
+		done.status = fmt.Sprintf("Checkpointed %s as %s.", target, name)
+		if exit {
+			done.status = fmt.Sprintf("Checkpointed %s as %s and stopped it.", target, name)
+		}
+		return done
+	})
+}
+
+// restoreCheckpoint starts a stopped container from a checkpoint, like
+// `docker start --checkpoint`.
+func (b *backend) restoreCheckpoint(id, target, name string) tea.Cmd {
+	return b.run(func(ctx context.Context, cli docker.Client) tea.Msg {
+		done := actionDoneMsg{id: id, action: "Restore", reload: resContainers}
+		start := time.Now()
+		err := cli.ContainerStart(ctx, id, container.StartOptions{CheckpointID: name})
+		logCall("ContainerStart", start, err, "id", format.Short12(id), "checkpoint", name)
+		if err != nil {
+			done.err = err
+			return done
+		}
+		done.status = fmt.Sprintf("Restored %s from %s.", target, name)
+		return done
+	})
+}
+
+// deleteCheckpoint deletes a checkpoint, like `docker checkpoint rm`.
+func (b *backend) deleteCheckpoint(id, target, name string) tea.Cmd {
+	return b.run(func(ctx context.Context, cli docker.Client) tea.Msg {
+		done := actionDoneMsg{id: id, action: "Delete checkpoint", reload: resContainers}
+		start := time.Now()
+		err := cli.CheckpointDelete(ctx, id, checkpoint.DeleteOptions{CheckpointID: name})
+		logCall("CheckpointDelete", start, err, "id", format.Short12(id), "checkpoint", name)
+		if err != nil {
+			done.err = err
+			return done
+		}
+		done.status = fmt.Sprintf("Deleted checkpoint %s of %s.", name, target)
+		return done
+	})
+}
@@ -0,0 +1,448 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Antityping/superdocker/docker"
+	"github.com/Antityping/superdocker/format"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	imagetypes "github.com/docker/docker/api/types/image"
+)
+
+// cleanupKind is a category of the cleanup view, in the order its
+// candidates are removed: containers first, so the images, volumes and
+// networks they held are free by the time those are removed.
+type cleanupKind int
+
+const (
+	cleanupContainers cleanupKind = iota
+	cleanupImages
+	cleanupVolumes
+	cleanupNetworks
+)
+
+var cleanupTitles = [...]string{"Stopped containers", "Dangling images", "Unused volumes", "Unused networks"}
+
+// cleanupNouns name one and several removed resources of each kind.
+var cleanupNouns = [...][2]string{
+	{"container", "containers"},
+	{"image", "images"},
+	{"volume", "volumes"},
+	{"network", "networks"},
+}
+
+// cleanupItem is one candidate of the cleanup view.
+type cleanupItem struct {
+	kind     cleanupKind
+	id       string // what the remove call takes
+	name     string
+	size     int64  // -1 when not known; networks have none
+	note     string // e.g. the stopped containers still holding it
+	selected bool
+}
+
+// cleanupView is the state of the cleanup screen: every cleanup candidate
+// of the loaded resources, grouped by kind.
+type cleanupView struct {
+	items    []cleanupItem
+	cursor   int
+	status   string // e.g. while the disk usage sizes load
+	question string // pending removal question, answered with y
+}
+
+type diskUsageMsg struct {
+	containers map[string]int64 // writable layer size by container ID
+	volumes    map[string]int64 // size by volume name
+	err        error
+}
+
+// cleanupCandidates collects what can go: stopped containers, dangling
+// images, and the volumes and non-predefined networks no running container
+// uses. Candidates only stopped containers still hold say so, since
+// removing them fails unless those containers go too.
+func (m model) cleanupCandidates() []cleanupItem {
+	running := func(c container.Summary) bool {
+		return c.State == "running" || c.State == "paused" || c.State == "restarting"
+	}
+	heldBy := func(held map[string][]string, key string, c container.Summary) {
+		held[key] = append(held[key], containerName(c))
+	}
+	// What running containers use, and what stopped ones still hold
+	usedImages, usedVolumes, usedNetworks := map[string]bool{}, map[string]bool{}, map[string]bool{}
+	heldImages, heldVolumes, heldNetworks := map[string][]string{}, map[string][]string{}, map[string][]string{}
+
+	var items []cleanupItem
+	for _, c := range m.containers {
+		on := running(c)
+		if on {
+			usedImages[c.ImageID] = true
+		} else {
+			heldBy(heldImages, c.ImageID, c)
+			size := int64(-1)
+			if sz, ok := m.sizes[c.ID]; ok {
+				size = sz.rw
+			}
+			items = append(items, cleanupItem{kind: cleanupContainers, id: c.ID, name: containerName(c), size: size, note: c.Status})
+		}
+		for _, mnt := range c.Mounts {
+			if mnt.Type != "volume" {
+				continue
+			}
+			if on {
+				usedVolumes[mnt.Name] = true
+			} else {
+				heldBy(heldVolumes, mnt.Name, c)
+			}
+		}
+		if c.NetworkSettings != nil {
+			for name := range c.NetworkSettings.Networks {
+				if on {
+					usedNetworks[name] = true
+				} else {
+					heldBy(heldNetworks, name, c)
+				}
+			}
+		}
+	}
+	held := func(names []string) string {
+		if len(names) == 0 {
+			return ""
+		}
+		return "held by stopped " + strings.Join(names, ", ")
+	}
+	for _, img := range m.images {
+		if isDangling(img) && !usedImages[img.ID] {
+			items = append(items, cleanupItem{kind: cleanupImages, id: img.ID, name: format.Short12(format.StripSha256(img.ID)), size: img.Size, note: held(heldImages[img.ID])})
+		}
+	}
+	for _, v := range m.volumes {
+		if usedVolumes[v.Name] {
+			continue
+		}
+		size := int64(-1)
+		if v.UsageData != nil && v.UsageData.Size >= 0 {
+			size = v.UsageData.Size
+		}
+		items = append(items, cleanupItem{kind: cleanupVolumes, id: v.Name, name: v.Name, size: size, note: held(heldVolumes[v.Name])})
+	}
+	for _, n := range m.networks {
+		if !isPredefinedNetwork(n) && !usedNetworks[n.Name] {
+			items = append(items, cleanupItem{kind: cleanupNetworks, id: n.ID, name: n.Name, size: -1, note: held(heldNetworks[n.Name])})
+		}
+	}
+	return items
+}
+
+// openCleanup shows the cleanup screen and loads the disk usage sizes the
+// lists lack: container writable layers and volume sizes.
+func (m model) openCleanup() (tea.Model, tea.Cmd) {
+	items := m.cleanupCandidates()
+	if len(items) == 0 {
+		m.status = "Nothing to clean up."
+		return m, nil
+	}
+	m.mode = viewCleanup
+	m.cleanup = &cleanupView{items: items, status: "loading sizes..."}
+	return m, m.backend.loadDiskUsage()
+}
+
+// loadDiskUsage asks the daemon for container and volume sizes, like
+// `docker system df -v`.
+func (b *backend) loadDiskUsage() tea.Cmd {
+	return b.run(func(ctx context.Context, cli docker.Client) tea.Msg {
+		start := time.Now()
+		du, err := cli.DiskUsage(ctx, types.DiskUsageOptions{Types: []types.DiskUsageObject{types.ContainerObject, types.VolumeObject}})
+		logCall("DiskUsage", start, err)
+		if err != nil {
+			return diskUsageMsg{err: err}
+		}
+		msg := diskUsageMsg{containers: map[string]int64{}, volumes: map[string]int64{}}
+		for _, c := range du.Containers {
+			msg.containers[c.ID] = c.SizeRw
+		}
+		for _, v := range du.Volumes {
+			if v.UsageData != nil && v.UsageData.Size >= 0 {
+				msg.volumes[v.Name] = v.UsageData.Size
+			}
+		}
+		return msg
+	})
+}
+
+// applyDiskUsage fills in the candidate sizes the disk usage reported.
+func (v *cleanupView) applyDiskUsage(msg diskUsageMsg) {
+	if msg.err != nil {
+		v.status = "sizes unavailable: " + msg.err.Error()
+		return
+	}
+	v.status = ""
+	for i := range v.items {
+		it := &v.items[i]
+		switch it.kind {
+		case cleanupContainers:
+			if sz, ok := msg.containers[it.id]; ok {
+				it.size = sz
+			}
+		case cleanupVolumes:
+			if sz, ok := msg.volumes[it.id]; ok {
+				it.size = sz
+			}
+		}
+	}
+}
+
+// selected returns the selected candidates, in removal order.
+func (v *cleanupView) selected() []cleanupItem {
+	var out []cleanupItem
+	for _, it := range v.items {
+		if it.selected {
+			out = append(out, it)
+		}
+	}
+	return out
+}
+
+// cleanupTotal sums the known sizes of items, reporting whether any is unknown.
+func cleanupTotal(items []cleanupItem) (total int64, unknown bool) {
+	for _, it := range items {
+		switch {
+		case it.size >= 0:
+			total += it.size
+		case it.kind != cleanupNetworks:
+			unknown = true
+		}
+	}
+	return total, unknown
+}
+
+// formatReclaim renders a reclaimable total, e.g. "at least 1.2 GB".
+func formatReclaim(total int64, unknown bool) string {
+	if unknown && total == 0 {
+		return "size unknown"
+	}
+	if unknown {
+		return "at least " + format.HumanizeBytes(total)
+	}
+	return format.HumanizeBytes(total)
+}
+
+// updateCleanup handles keys on the cleanup screen.
+func (m model) updateCleanup(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	v := m.cleanup
+	if v.question != "" {
+		v.question = ""
+		if msg.String() == "y" {
+			return m.runCleanup()
+		}
+		if msg.String() == "ctrl+c" {
+			return m.quit()
+		}
+		return m, nil
+	}
+	switch msg.String() {
+	case "ctrl+c":
+		return m.quit()
+	case "esc", "q":
+		m.cleanup = nil
+		m.mode = viewDashboard
+		return m, nil
+	case "up", "k":
+		v.cursor = max(v.cursor-1, 0)
+	case "down", "j":
+		v.cursor = min(v.cursor+1, len(v.items)-1)
+	case "pgup":
+		v.cursor = max(v.cursor-m.cleanupHeight(), 0)
+	case "pgdown":
+		v.cursor = min(v.cursor+m.cleanupHeight(), len(v.items)-1)
+	case " ", "space":
+		v.items[v.cursor].selected = !v.items[v.cursor].selected
+	case "a":
+		// Select the cursor's whole category, or clear it if all selected
+		kind := v.items[v.cursor].kind
+		all := true
+		for _, it := range v.items {
+			if it.kind == kind && !it.selected {
+				all = false
+			}
+		}
+		for i := range v.items {
+			if v.items[i].kind == kind {
+				v.items[i].selected = !all
+			}
+		}
+	case "A":
+		all := !slices.ContainsFunc(v.items, func(it cleanupItem) bool { return !it.selected })
+		for i := range v.items {
+			v.items[i].selected = !all
+		}
+	case "enter", "d":
+		sel := v.selected()
+		if len(sel) == 0 {
+			return m, nil
+		}
+		if m.readOnly {
+			v.status = readOnlyStatus
+			return m, nil
+		}
+		if m.noConfirm {
+			return m.runCleanup()
+		}
+		total, unknown := cleanupTotal(sel)
+		v.question = fmt.Sprintf("Remove %s, reclaiming %s?", countCleanup(sel), formatReclaim(total, unknown))
+	}
+	return m, nil
+}
+
+// countCleanup counts items by kind, e.g. "2 containers, 1 image".
+func countCleanup(items []cleanupItem) string {
+	var counts [len(cleanupTitles)]int
+	for _, it := range items {
+		counts[it.kind]++
+	}
+	var parts []string
+	for k, n := range counts {
+		if n > 0 {
+			noun := cleanupNouns[k][1]
+			if n == 1 {
+				noun = cleanupNouns[k][0]
+			}
+			parts = append(parts, fmt.Sprintf("%d %s", n, noun))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// runCleanup leaves the cleanup screen and removes the selected
+// candidates, after the remote host confirmation where that applies.
+func (m model) runCleanup() (tea.Model, tea.Cmd) {
+	sel := m.cleanup.selected()
+	m.cleanup = nil
+	m.mode = viewDashboard
+	return m.confirmRemote(confirmChoice{
+		label:  "remove " + countCleanup(sel),
+		status: "Cleaning up...",
+		cmd:    m.backend.removeCleanup(sel),
+	})
+}
+
+// removeCleanup removes items in order and reports what went and the
+// space reclaimed. A failed removal is counted but doesn't stop the rest.
+func (b *backend) removeCleanup(items []cleanupItem) tea.Cmd {
+	return b.run(func(ctx context.Context, cli docker.Client) tea.Msg {
+		done := actionDoneMsg{action: "Clean up", reload: allResources}
+		var removed []cleanupItem
+		var firstErr error
+		failed := 0
+		for _, it := range items {
+			start := time.Now()
+			var err error
+			switch it.kind {
+			case cleanupContainers:
+				err = cli.ContainerRemove(ctx, it.id, container.RemoveOptions{})
+				logCall("ContainerRemove", start, err, "id", format.Short12(it.id))
+			case cleanupImages:
+				_, err = cli.ImageRemove(ctx, it.id, imagetypes.RemoveOptions{PruneChildren: true})
+				logCall("ImageRemove", start, err, "id", format.Short12(format.StripSha256(it.id)))
+			case cleanupVolumes:
+				err = cli.VolumeRemove(ctx, it.id, false)
+				logCall("VolumeRemove", start, err, "name", it.id)
+			case cleanupNetworks:
+				err = cli.NetworkRemove(ctx, it.id)
+				logCall("NetworkRemove", start, err, "id", format.Short12(it.id))
+			}
+			if err != nil {
+				failed++
+				if firstErr == nil {
+					firstErr = fmt.Errorf("%s: %w", it.name, err)
+				}
+				continue
+			}
+			removed = append(removed, it)
+		}
+
+		total, unknown := cleanupTotal(removed)
+		what := countCleanup(removed)
+		if what == "" {
+			what = "nothing"
+		}
+		done.status = fmt.Sprintf("Removed %s; reclaimed %s.", what, formatReclaim(total, unknown))
+		if firstErr != nil {
+			done.status = fmt.Sprintf("Removed %s; reclaimed %s; %d failed (first error: %v).", what, formatReclaim(total, unknown), failed, firstErr)
+		}
+		return done
+	})
+}
+
+// cleanupHeight is how many lines of candidates the cleanup screen shows.
+func (m model) cleanupHeight() int {
+	return max(m.height-4, 5)
+}
+
+// cleanupView renders the cleanup screen: the candidates under a header per
+// category with its count and size, scrolled to keep the cursor shown.
+func (m model) cleanupView() string {
+	v := m.cleanup
+	var lines []string
+	cursorLine := 0
+	for i, it := range v.items {
+		if i == 0 || v.items[i-1].kind != it.kind {
+			var group []cleanupItem
+			for _, g := range v.items {
+				if g.kind == it.kind {
+					group = append(group, g)
+				}
+			}
+			header := fmt.Sprintf("%s (%d", cleanupTitles[it.kind], len(group))
+			if it.kind != cleanupNetworks {
+				header += ", " + formatReclaim(cleanupTotal(group))
+			}
+			lines = append(lines, titleStyle.Render(header+")"))
+		}
+		box := "[ ]"
+		if it.selected {
+			box = "[x]"
+		}
+		size := ""
+		switch {
+		case it.kind == cleanupNetworks:
+		case it.size >= 0:
+			size = format.HumanizeBytes(it.size)
+		default:
+			size = "?"
+		}
+		line := fmt.Sprintf("%s %-40s %10s", box, format.TrimTo(it.name, 40), size)
+		if i == v.cursor {
+			line = columnCursorStyle.Render(line)
+			cursorLine = len(lines)
+		}
+		if it.note != "" {
+			line += helpStyle.Render("  " + it.note)
+		}
+		lines = append(lines, "  "+line)
+	}
+	height := m.cleanupHeight()
+	top := max(0, min(cursorLine-height/2, len(lines)-height))
+	lines = lines[top:min(top+height, len(lines))]
+
+	sel := v.selected()
+	total, unknown := cleanupTotal(v.items)
+	title := fmt.Sprintf("Clean up (%d candidates, %s reclaimable)", len(v.items), formatReclaim(total, unknown))
+	footer := helpStyle.Render("  ↑/↓: move • space: select • a: select group • A: select all • d/enter: remove selected • esc: back")
+	switch {
+	case v.question != "":
+		footer = confirmStyle.Render(v.question + "  [y: remove • any other key: cancel]")
+	case len(sel) > 0:
+		selTotal, selUnknown := cleanupTotal(sel)
+		footer += statusStyle.Render(fmt.Sprintf("selected %s (%s)", countCleanup(sel), formatReclaim(selTotal, selUnknown)))
+	}
+	if v.status != "" {
+		footer += statusStyle.Render(v.status)
+	}
+	return fmt.Sprintf("%s\n%s\n%s", titleStyle.Render(title), strings.Join(lines, "\n"), footer)
+}
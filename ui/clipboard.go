@@ -0,0 +1,29 @@
+package ui
+
+import (
+	"errors"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// clipboardMsg reports the outcome of copyToClipboard.
+type clipboardMsg struct {
+	done string // status to show on success
+	err  error
+}
+
+// copyToClipboard writes text to the system clipboard, reporting done on
+// success. On Linux this needs xclip, xsel or wl-copy, so a missing tool
+// is reported instead of failing silently.
+func copyToClipboard(text, done string) tea.Cmd {
+	return func() tea.Msg {
+		if clipboard.Unsupported {
+			return clipboardMsg{err: errors.New("no clipboard available (install xclip, xsel or wl-clipboard)")}
+		}
+		if err := clipboard.WriteAll(text); err != nil {
+			return clipboardMsg{err: err}
+		}
+		return clipboardMsg{done: done}
+	}
+}
@@ -0,0 +1,212 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// tableNames identifies each table by focus index in the preferences file.
+var tableNames = [5]string{"containers", "images", "volumes", "networks", "services"}
+
+// columnSpec describes one table column. Rows always carry a value for
+// every column; hidden columns are rendered with zero width.
+type columnSpec struct {
+	title  string
+	width  int
+	hidden bool // hidden unless enabled in the column picker
+	fixed  bool // always shown and not offered in the column picker
+}
+
+// defaultColumns are the columns of each table, indexed like focusIndex.
+var defaultColumns = [5][]columnSpec{
+	{
+		{title: "Container ID", width: 12},
+		{title: "Image", width: 25},
+		{title: "Command", width: 20, hidden: true},
+		{title: "Status", width: 18, hidden: true},
+		{title: "Name", width: 20, hidden: true},
+		{title: "CPU %", width: 8},
+		{title: "Mem", width: 9},
+		{title: "Net I/O", width: 20, hidden: true},
+		{title: "Ports", width: 15, hidden: true},
+		{title: "Size", width: 18, hidden: true},
+		{title: "Age", width: 10},
+		{title: "", width: 1, fixed: true}, // pending action indicator
+	},
+	{
+		{title: "Repository:Tag", width: 30},
+		{title: "Image ID", width: 12},
+		{title: "Size", width: 10},
+		{title: "Age", width: 10},
+	},
+	{
+		{title: "Name", width: 25},
+		{title: "Driver", width: 12},
+		{title: "Mountpoint", width: 40},
+		{title: "Age", width: 10},
+	},
+	{
+		{title: "Name", width: 22},
+		{title: "Network ID", width: 12},
+		{title: "Driver", width: 10},
+		{title: "Scope", width: 10},
+		{title: "Age", width: 10},
+	},
+	{
+		{title: "Name", width: 22},
+		{title: "Mode", width: 11},
+		{title: "Replicas", width: 8},
+		{title: "Image", width: 30},
+		{title: "Age", width: 10},
+	},
+}
+
+// columnsFromPrefs returns the column specs for a table with visibility
+// taken from the saved preferences when present.
+func columnsFromPrefs(i int, p preferences) []columnSpec {
+	cols := make([]columnSpec, len(defaultColumns[i]))
+	copy(cols, defaultColumns[i])
+	if j := idColumns[i]; j >= 0 {
+		cols[j].width = idColumnWidth(p.FullIDs)
+	}
+	visible, ok := p.Columns[tableNames[i]]
+	if !ok {
+		return cols
+	}
+	show := map[string]bool{}
+	for _, t := range visible {
+		show[t] = true
+	}
+	for j := range cols {
+		if !cols[j].fixed {
+			cols[j].hidden = !show[cols[j].title]
+		}
+	}
+	return cols
+}
+
+// tableColumns converts specs to table columns, giving hidden ones no width.
+func tableColumns(specs []columnSpec) []table.Column {
+	cols := make([]table.Column, len(specs))
+	for i, s := range specs {
+		cols[i] = table.Column{Title: s.title, Width: s.width}
+		if s.hidden {
+			cols[i].Width = 0
+		}
+	}
+	return cols
+}
+
+// tableAt returns a pointer to the table with the given focus index.
+func (m *model) tableAt(i int) *table.Model {
+	switch i {
+	case 1:
+		return &m.imagesTable
+	case 2:
+		return &m.volumesTable
+	case 3:
+		return &m.networksTable
+	case 4:
+		return &m.servicesTable
+	default:
+		return &m.containersTable
+	}
+}
+
+// applyColumns pushes the column specs of table i to its table widget.
+func (m *model) applyColumns(i int) {
+	m.tableAt(i).SetColumns(m.markSortColumn(i, tableColumns(m.columns[i])))
+}
+
+// pickableColumns returns the indexes of columns offered in the picker.
+func (m model) pickableColumns() []int {
+	var idx []int
+	for j, c := range m.columns[m.focusIndex] {
+		if !c.fixed {
+			idx = append(idx, j)
+		}
+	}
+	return idx
+}
+
+// updateColumnPicker handles keys while choosing visible columns.
+func (m model) updateColumnPicker(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	pick := m.pickableColumns()
+	switch msg.String() {
+	case "ctrl+c":
+		return m.quit()
+	case "left", "h":
+		if m.columnCursor > 0 {
+			m.columnCursor--
+		}
+	case "right", "l":
+		if m.columnCursor < len(pick)-1 {
+			m.columnCursor++
+		}
+	case " ", "x":
+		m.toggleColumn(pick[m.columnCursor])
+	case "esc", "enter", "c":
+		m.columnPicker = false
+		m.prefs.Columns = map[string][]string{}
+		for i := range m.columns {
+			var visible []string
+			for _, c := range m.columns[i] {
+				if !c.fixed && !c.hidden {
+					visible = append(visible, c.title)
+				}
+			}
+			m.prefs.Columns[tableNames[i]] = visible
+		}
+		if err := savePrefs(m.prefs); err != nil {
+			m.status = fmt.Sprintf("Could not save preferences: %v", err)
+		}
+	}
+	return m, nil
+}
+
+// toggleColumn flips the visibility of column j of the focused table,
+// refusing to hide the last visible column.
+func (m *model) toggleColumn(j int) {
+	cols := m.columns[m.focusIndex]
+	if !cols[j].hidden {
+		visible := 0
+		for _, c := range cols {
+			if !c.fixed && !c.hidden {
+				visible++
+			}
+		}
+		if visible == 1 {
+			m.status = "At least one column must stay visible."
+			return
+		}
+	}
+	cols[j].hidden = !cols[j].hidden
+	m.applyColumns(m.focusIndex)
+}
+
+// columnPickerView renders the column picker in the footer.
+func (m model) columnPickerView() string {
+	cols := m.columns[m.focusIndex]
+	items := []string{}
+	for n, j := range m.pickableColumns() {
+		box := "[x]"
+		if cols[j].hidden {
+			box = "[ ]"
+		}
+		item := fmt.Sprintf("%s %s", box, cols[j].title)
+		if n == m.columnCursor {
+			item = columnCursorStyle.Render(item)
+		}
+		items = append(items, item)
+	}
+	return promptStyle.Render(fmt.Sprintf("Columns (%s): %s   ←/→: move • space: toggle • enter: done",
+		tableNames[m.focusIndex], strings.Join(items, "  ")))
+}
+
+var columnCursorStyle = lipgloss.NewStyle().
+	Foreground(lipgloss.Color("229")).
+	Background(lipgloss.Color("63"))
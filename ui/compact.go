@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// tableHeights are the table heights, header included, of the regular layout.
+var tableHeights = [5]int{12, 8, 8, 12, 8}
+
+// compactTitleStyle is titleStyle without padding, for compact mode.
+var compactTitleStyle = titleStyle.Padding(0)
+
+// tableStyles returns the styles for a focused or blurred table. Compact
+// mode drops the header border and all padding but one space between cells.
+func (m model) tableStyles(focused bool) table.Styles {
+	s := m.stylesBlurred
+	if focused {
+		s = m.stylesFocused
+	}
+	if m.compact {
+		s.Header = s.Header.BorderBottom(false).Padding(0, 1, 0, 0)
+		s.Cell = s.Cell.Padding(0, 1, 0, 0)
+	}
+	return s
+}
+
+// setCompact switches compact mode on or off, restyling and resizing the
+// tables to match.
+func (m *model) setCompact(on bool) {
+	m.compact = on
+	m.focusTable(m.focusIndex)
+	m.resizeTables()
+}
+
+// resizeTables sets the table heights for the current mode. Compact mode
+// shares the terminal height between the shown tables; the regular layout
+// uses fixed heights.
+func (m *model) resizeTables() {
+	shown := 0
+	for i := range tableNames {
+		if m.tableVisible(i) {
+			shown++
+		}
+	}
+	for i := range tableHeights {
+		h := tableHeights[i]
+		if m.compact && m.height > 0 {
+			// Leave room for the summary, footer and help lines, the
+			// banners and host line, and one title line per table
+			chrome := 5
+			if m.remoteHost != "" {
+				chrome++
+			}
+			if m.readOnly {
+				chrome++
+			}
+			if m.noConfirmBanner() != "" {
+				chrome++
+			}
+			if m.hostInfo != nil {
+				chrome++
+			}
+			h = max((m.height-chrome)/shown-1, 2)
+		}
+		m.tableAt(i).SetHeight(h)
+	}
+}
+
+// compactView renders the tables stacked without borders, each under a
+// one-line title, beside an unbordered info panel.
+func (m model) compactView() string {
+	lw, rw := m.paneWidths()
+	titles := m.tableTitles()
+	sections := make([]string, 0, 2*len(titles))
+	for i, title := range titles {
+		if !m.tableVisible(i) {
+			continue
+		}
+		t := m.tableAt(i)
+		t.SetWidth(lw)
+		if i == m.focusIndex {
+			title = "▸ " + title
+		} else {
+			title = "  " + title
+		}
+		sections = append(sections, clipWidth(compactTitleStyle.Render(title), lw), clipWidth(t.View(), lw))
+	}
+	left := lipgloss.NewStyle().Width(lw).Render(lipgloss.JoinVertical(lipgloss.Left, sections...))
+	if m.layout.hideInfo {
+		return left
+	}
+
+	infoTitle, infoBody := m.infoTitleAndBody()
+	info := lipgloss.NewStyle().Width(rw).PaddingLeft(1).Render(infoTitle + "\n" + infoBody)
+	return lipgloss.JoinHorizontal(lipgloss.Top, left, clipLines(info, lipgloss.Height(left)))
+}
@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Antityping/superdocker/format"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// copyCommand copies a docker command for the selected resource to the
+// clipboard. Pressing the key again on the same selection copies the next
+// command, wrapping around, so a few common ones are a keypress away.
+func (m model) copyCommand() (tea.Model, tea.Cmd) {
+	cmds := m.selectionCommands()
+	if len(cmds) == 0 {
+		m.status = "Nothing selected."
+		return m, nil
+	}
+	key := m.selectionKey()
+	if key != m.copyKey {
+		m.copyKey, m.copyNext = key, 0
+	}
+	text := cmds[m.copyNext%len(cmds)]
+	m.copyNext++
+	return m, copyToClipboard(text, fmt.Sprintf("Copied: %s (y: next command)", text))
+}
+
+// selectionCommands returns the docker commands offered for the selected
+// resource, most useful first.
+func (m model) selectionCommands() []string {
+	switch m.focusIndex {
+	case 0:
+		c := m.selectedContainer()
+		if c == nil {
+			return nil
+		}
+		ref := containerName(*c)
+		if strings.HasPrefix(ref, "<") {
+			ref = format.Short12(c.ID)
+		}
+		return []string{
+			"docker logs -f " + ref,
+			"docker exec -it " + ref + " sh",
+			"docker inspect " + ref,
+			"docker stats " + ref,
+		}
+	case 1:
+		img := m.selectedImage()
+		if img == nil {
+			return nil
+		}
+		ref := m.imageName(img.ID)
+		cmds := []string{
+			"docker run --rm -it " + ref,
+			"docker history " + ref,
+			"docker image inspect " + ref,
+		}
+		// Untagged images have nothing to pull
+		if ref != format.Short12(format.StripSha256(img.ID)) {
+			cmds = append(cmds, "docker pull "+ref)
+		}
+		return cmds
+	case 2:
+		v := m.selectedVolume()
+		if v == nil {
+			return nil
+		}
+		return []string{
+			"docker run --rm -it -v " + v.Name + ":/data alpine sh",
+			"docker volume inspect " + v.Name,
+		}
+	case 3:
+		n := m.selectedNetwork()
+		if n == nil {
+			return nil
+		}
+		return []string{
+			"docker run --rm -it --network " + n.Name + " alpine sh",
+			"docker network inspect " + n.Name,
+		}
+	case 4:
+		s := m.selectedService()
+		if s == nil {
+			return nil
+		}
+		return []string{
+			"docker service ps " + s.Spec.Name,
+			"docker service logs -f " + s.Spec.Name,
+			"docker service inspect " + s.Spec.Name,
+		}
+	}
+	return nil
+}
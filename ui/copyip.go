@@ -0,0 +1,76 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/docker/docker/api/types/container"
+)
+
+// containerAddress is a container's address on one of its networks.
+type containerAddress struct {
+	network, ip string
+}
+
+// containerAddresses lists the addresses of c by network, the default
+// bridge first and the rest by name. IPv6 stands in on networks without
+// an IPv4 address; networks without either, such as host, are left out.
+func containerAddresses(c container.Summary) []containerAddress {
+	if c.NetworkSettings == nil {
+		return nil
+	}
+	var addrs []containerAddress
+	for name, ep := range c.NetworkSettings.Networks {
+		if ep == nil {
+			continue
+		}
+		ip := ep.IPAddress
+		if ip == "" {
+			ip = ep.GlobalIPv6Address
+		}
+		if ip != "" {
+			addrs = append(addrs, containerAddress{network: name, ip: ip})
+		}
+	}
+	sort.Slice(addrs, func(i, j int) bool {
+		if (addrs[i].network == "bridge") != (addrs[j].network == "bridge") {
+			return addrs[i].network == "bridge"
+		}
+		return addrs[i].network < addrs[j].network
+	})
+	return addrs
+}
+
+// copyIP copies the selected container's IP address to the clipboard,
+// asking which network's when it has several.
+func (m model) copyIP() (tea.Model, tea.Cmd) {
+	c := m.selectedContainer()
+	if c == nil {
+		m.status = "No container selected."
+		return m, nil
+	}
+	name := containerName(*c)
+	addrs := containerAddresses(*c)
+	switch len(addrs) {
+	case 0:
+		m.status = fmt.Sprintf("%s has no IP address (not running, or on the host network).", name)
+		return m, nil
+	case 1:
+		return m.copyIPOf(name, addrs[0])
+	}
+	choices := make([]confirmChoice, 0, maxPickChoices)
+	for i, a := range addrs[:min(len(addrs), maxPickChoices)] {
+		choices = append(choices, confirmChoice{
+			key:   fmt.Sprint(i + 1),
+			label: fmt.Sprintf("%s %s", a.network, a.ip),
+			open:  func(m model) (tea.Model, tea.Cmd) { return m.copyIPOf(name, a) },
+		})
+	}
+	return m.askPick(fmt.Sprintf("Copy %s's IP on which network?", name), choices...)
+}
+
+// copyIPOf copies one address picked in copyIP.
+func (m model) copyIPOf(name string, a containerAddress) (tea.Model, tea.Cmd) {
+	return m, copyToClipboard(a.ip, fmt.Sprintf("Copied %s's IP on %s: %s", name, a.network, a.ip))
+}
@@ -0,0 +1,100 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// copyTable copies the focused table as it stands, filtered, sorted and
+// with the visible columns only, for pasting into a chat or a ticket:
+// as aligned plain text, or as a markdown table.
+func (m model) copyTable(markdown bool) (tea.Model, tea.Cmd) {
+	headers, rows := m.plainTable(m.focusIndex)
+	if len(rows) == 0 {
+		m.status = "Nothing to copy."
+		return m, nil
+	}
+	as := "text"
+	if markdown {
+		as = "markdown"
+	}
+	done := fmt.Sprintf("Copied %d %s rows as %s.", len(rows), tableNames[m.focusIndex], as)
+	return m, copyToClipboard(renderPlainTable(headers, rows, markdown), done)
+}
+
+// plainTable returns the visible columns and rows of table i as plain
+// cells.
+func (m model) plainTable(i int) (headers []string, rows [][]string) {
+	var cols []int
+	for j, c := range m.columns[i] {
+		// The untitled indicator column holds spinners and markers
+		if !c.hidden && c.title != "" {
+			cols = append(cols, j)
+			headers = append(headers, c.title)
+		}
+	}
+	for _, r := range m.tableAt(i).Rows() {
+		cells := make([]string, len(cols))
+		for k, j := range cols {
+			if j < len(r) {
+				cells[k] = r[j]
+			}
+		}
+		rows = append(rows, cells)
+	}
+	return headers, rows
+}
+
+// renderPlainTable lays out headers and rows in padded columns, or as a
+// markdown pipe table.
+func renderPlainTable(headers []string, rows [][]string, markdown bool) string {
+	if markdown {
+		esc := func(cells []string) []string {
+			out := make([]string, len(cells))
+			for i, c := range cells {
+				out[i] = strings.ReplaceAll(c, "|", `\|`)
+			}
+			return out
+		}
+		rule := make([]string, len(headers))
+		for i := range rule {
+			rule[i] = "---"
+		}
+		lines := []string{
+			"| " + strings.Join(esc(headers), " | ") + " |",
+			"| " + strings.Join(rule, " | ") + " |",
+		}
+		for _, r := range rows {
+			lines = append(lines, "| "+strings.Join(esc(r), " | ")+" |")
+		}
+		return strings.Join(lines, "\n") + "\n"
+	}
+
+	widths := make([]int, len(headers))
+	for i, h := range headers {
+		widths[i] = lipgloss.Width(h)
+	}
+	for _, r := range rows {
+		for i, c := range r {
+			widths[i] = max(widths[i], lipgloss.Width(c))
+		}
+	}
+	line := func(cells []string) string {
+		var b strings.Builder
+		for i, c := range cells {
+			b.WriteString(c)
+			if i < len(cells)-1 {
+				b.WriteString(strings.Repeat(" ", widths[i]-lipgloss.Width(c)+2))
+			}
+		}
+		return b.String()
+	}
+	lines := []string{line(headers)}
+	for _, r := range rows {
+		lines = append(lines, line(r))
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
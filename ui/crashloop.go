@@ -0,0 +1,111 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+const (
+	// crashWindow is how far back observed restarts count towards a loop.
+	crashWindow = 10 * time.Minute
+	// crashRestarts is the restart count, observed within crashWindow or
+	// reported by the daemon, from which a container is flagged.
+	crashRestarts = 3
+	// crashUptime is the uptime under which a container with many restarts
+	// counts as still looping rather than recovered.
+	crashUptime = time.Minute
+	// crashMarker flags crash-looping containers in the indicator column.
+	crashMarker = "⚠"
+)
+
+// restartingExit matches the exit code in a restarting container's
+// status, e.g. "Restarting (1) 3 seconds ago".
+var restartingExit = regexp.MustCompile(`^Restarting \((-?\d+)\)`)
+
+// restartTrack is what the reloads have seen of one container's restarts:
+// the list data has no restart count, so entering the restarting state is
+// counted instead.
+type restartTrack struct {
+	state    string
+	restarts []time.Time // when the container was seen entering restarting
+	exit     string      // exit code of the last crash, from the status
+}
+
+// trackRestarts records, after a containers reload, which containers have
+// entered the restarting state since the previous one.
+func (m *model) trackRestarts(cs []container.Summary) {
+	now := time.Now()
+	seen := make(map[string]bool, len(cs))
+	for _, c := range cs {
+		seen[c.ID] = true
+		tr, ok := m.restarts[c.ID]
+		if !ok {
+			tr = &restartTrack{}
+			m.restarts[c.ID] = tr
+		}
+		if c.State == "restarting" && tr.state != "restarting" {
+			tr.restarts = append(tr.restarts, now)
+		}
+		if sm := restartingExit.FindStringSubmatch(c.Status); sm != nil {
+			tr.exit = sm[1]
+		}
+		tr.state = c.State
+		for len(tr.restarts) > 0 && now.Sub(tr.restarts[0]) > crashWindow {
+			tr.restarts = tr.restarts[1:]
+		}
+	}
+	for id := range m.restarts {
+		if !seen[id] {
+			delete(m.restarts, id)
+		}
+	}
+}
+
+// crashLoop reports whether the container with id looks crash looping,
+// with the exit code of its last crash and its restart count. Inspect
+// data, when fetched, adds the daemon's own restart count: many restarts
+// with a short uptime still count as a loop between the reloads.
+func (m model) crashLoop(id string) (exit string, restarts int, looping bool) {
+	if tr, ok := m.restarts[id]; ok {
+		exit, restarts = tr.exit, len(tr.restarts)
+		looping = restarts >= crashRestarts
+	}
+	ci, ok := m.inspected[id]
+	if !ok || ci.ContainerJSONBase == nil || ci.State == nil {
+		return exit, restarts, looping
+	}
+	st := ci.State
+	if ci.RestartCount > restarts {
+		restarts = ci.RestartCount
+	}
+	started, err := time.Parse(time.RFC3339Nano, st.StartedAt)
+	young := err == nil && time.Since(started) < crashUptime
+	if ci.RestartCount >= crashRestarts && (st.Restarting || young) {
+		looping = true
+	}
+	if !st.Running || st.Restarting {
+		exit = fmt.Sprint(st.ExitCode)
+	}
+	return exit, restarts, looping
+}
+
+// renderCrashLoop renders the info panel's crash-loop warning for id, e.g.
+// "⚠ crash looping (exit 1, 5 restarts)", or "" when it isn't looping.
+func (m model) renderCrashLoop(id string) string {
+	exit, restarts, looping := m.crashLoop(id)
+	if !looping {
+		return ""
+	}
+	detail := fmt.Sprintf("%d restarts", restarts)
+	if exit != "" {
+		detail = fmt.Sprintf("exit %s, %s", exit, detail)
+	}
+	warning := exitErrorStyle.Render(fmt.Sprintf("%s crash looping (%s)", crashMarker, detail))
+	if ci, ok := m.inspected[id]; ok && ci.ContainerJSONBase != nil && ci.State != nil && ci.State.Error != "" {
+		warning += "\nLast error: " + ci.State.Error
+	}
+	return warning
+}
@@ -0,0 +1,155 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/docker/docker/api/types/filters"
+)
+
+// daemonFilterKeys are the `--filter` keys each table's list call accepts,
+// as documented for `docker ps`, `docker images`, `docker volume ls`,
+// `docker network ls` and `docker service ls`.
+var daemonFilterKeys = [len(tableNames)][]string{
+	{"ancestor", "before", "expose", "exited", "health", "id", "is-task", "isolation", "label", "name", "network", "publish", "since", "status", "volume"},
+	{"before", "dangling", "label", "reference", "since", "until"},
+	{"dangling", "driver", "label", "name"},
+	{"dangling", "driver", "id", "label", "name", "scope", "type"},
+	{"id", "label", "mode", "name"},
+}
+
+// daemonFilterValues are the accepted values of the keys that take one of
+// a fixed set.
+var daemonFilterValues = map[string][]string{
+	"status":    {"created", "restarting", "running", "removing", "paused", "exited", "dead"},
+	"health":    {"starting", "healthy", "unhealthy", "none"},
+	"isolation": {"default", "process", "hyperv"},
+	"dangling":  {"true", "false"},
+	"is-task":   {"true", "false"},
+	"scope":     {"swarm", "global", "local"},
+	"type":      {"custom", "builtin"},
+	"mode":      {"replicated", "global"},
+}
+
+// portFilterPattern matches the values of publish and expose: a port or
+// port range with an optional protocol, e.g. "8080" or "8000-8080/tcp".
+var portFilterPattern = regexp.MustCompile(`^\d{1,5}(-\d{1,5})?(/(tcp|udp|sctp))?$`)
+
+// parseDaemonFilter parses `docker --filter` style terms for table i, e.g.
+// "status=exited label=env=prod", checking each key and, where the daemon
+// only takes certain values, the value, so a typo is reported here rather
+// than as an opaque daemon error or an empty table. Repeated keys are
+// combined as the daemon does: any value of the same key matches, while
+// different keys must all match.
+func parseDaemonFilter(i int, s string) (filters.Args, error) {
+	args := filters.NewArgs()
+	for _, term := range strings.Fields(s) {
+		key, value, ok := strings.Cut(term, "=")
+		if !ok || value == "" {
+			return filters.Args{}, fmt.Errorf("%q: filters are key=value, e.g. status=exited", term)
+		}
+		if !slices.Contains(daemonFilterKeys[i], key) {
+			return filters.Args{}, fmt.Errorf("%s can't be filtered by %q; try %s", tableNames[i], key, strings.Join(daemonFilterKeys[i], ", "))
+		}
+		if allowed, ok := daemonFilterValues[key]; ok && !slices.Contains(allowed, value) {
+			return filters.Args{}, fmt.Errorf("%s must be one of %s", key, strings.Join(allowed, ", "))
+		}
+		switch key {
+		case "exited":
+			if _, err := strconv.Atoi(value); err != nil {
+				return filters.Args{}, errors.New("exited takes an exit code, e.g. exited=137")
+			}
+		case "publish", "expose":
+			if !portFilterPattern.MatchString(value) {
+				return filters.Args{}, fmt.Errorf("%s takes a port or range, e.g. %s=8080/tcp", key, key)
+			}
+		case "label":
+			if strings.HasPrefix(value, "=") {
+				return filters.Args{}, errors.New("label takes a key or key=value, e.g. label=env=prod")
+			}
+		}
+		args.Add(key, value)
+	}
+	return args, nil
+}
+
+// openDaemonFilterPrompt asks for the daemon-side filter of the focused
+// table, prefilled with the current one.
+func (m model) openDaemonFilterPrompt() (tea.Model, tea.Cmd) {
+	m.daemonFilterTable = m.focusIndex
+	label := fmt.Sprintf("Daemon filter for %s: ", tableNames[m.focusIndex])
+	return m.openPrompt(promptDaemonFilter, label, m.daemonFilters[m.focusIndex])
+}
+
+// daemonFilterPreview renders the line under the daemon filter prompt:
+// what the list call will be asked for, or why the input can't be sent.
+func (m model) daemonFilterPreview() string {
+	value := m.input.Value()
+	args, err := parseDaemonFilter(m.daemonFilterTable, value)
+	switch {
+	case err != nil:
+		return exitErrorStyle.Render("  ✗ " + err.Error())
+	case args.Len() == 0:
+		return helpStyle.Render(fmt.Sprintf("  like docker --filter: %s=… • empty: list all %s",
+			strings.Join(daemonFilterKeys[m.daemonFilterTable], "=… "), tableNames[m.daemonFilterTable]))
+	}
+	return helpStyle.Render(fmt.Sprintf("  list %s where %s   enter: apply • esc: cancel",
+		tableNames[m.daemonFilterTable], describeFilter(args)))
+}
+
+// describeFilter renders args as the daemon applies them, e.g.
+// "status=exited|dead and label=env=prod".
+func describeFilter(args filters.Args) string {
+	keys := args.Keys()
+	slices.Sort(keys)
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		values := args.Get(k)
+		slices.Sort(values)
+		parts[i] = k + "=" + strings.Join(values, "|")
+	}
+	return strings.Join(parts, " and ")
+}
+
+// applyDaemonFilter sets the daemon-side filter of table i and re-lists it.
+func (m model) applyDaemonFilter(i int, value string) (tea.Model, tea.Cmd) {
+	args, err := parseDaemonFilter(i, value)
+	if err != nil {
+		m.status = fmt.Sprintf("Daemon filter not applied: %v", err)
+		return m, nil
+	}
+	m.daemonFilters[i] = strings.Join(strings.Fields(value), " ")
+	m.backend.setListFilter(i, args)
+	m.status = fmt.Sprintf("Daemon filter for %s cleared.", tableNames[i])
+	if m.daemonFilters[i] != "" {
+		m.status = fmt.Sprintf("Listing %s where %s.", tableNames[i], m.daemonFilters[i])
+	}
+	return m, m.backend.load(resourceSet(1) << i)
+}
+
+// setListFilter sets the filter the list call of table i sends.
+func (b *backend) setListFilter(i int, args filters.Args) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.listFilters[i] = args
+}
+
+// listFilterSet returns the filters the list calls send, by table. Tables
+// without one get empty filters.
+func (b *backend) listFilterSet() [len(tableNames)]filters.Args {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var set [len(tableNames)]filters.Args
+	for i, args := range b.listFilters {
+		set[i] = filters.NewArgs()
+		if args.Len() > 0 {
+			set[i] = args.Clone()
+		}
+	}
+	return set
+}
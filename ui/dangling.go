@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Antityping/superdocker/docker"
+	"github.com/Antityping/superdocker/format"
+	tea "github.com/charmbracelet/bubbletea"
+	imagetypes "github.com/docker/docker/api/types/image"
+)
+
+// isDangling reports whether img has no tag, as listed by
+// `docker images --filter dangling=true`.
+func isDangling(img imagetypes.Summary) bool {
+	return len(img.RepoTags) == 0 || (len(img.RepoTags) == 1 && img.RepoTags[0] == "<none>:<none>")
+}
+
+// unusedMarker flags dangling images no container uses, the images a
+// dangling clean or prune removes.
+const unusedMarker = "⚠ unused"
+
+// usedImageIDs returns the IDs of images any loaded container was created
+// from. Image summaries only count containers when the daemon was asked to,
+// so this cross-references the container list instead.
+func (m model) usedImageIDs() map[string]bool {
+	used := make(map[string]bool, len(m.containers))
+	for _, c := range m.containers {
+		used[c.ImageID] = true
+	}
+	return used
+}
+
+// danglingImages returns the loaded images without a tag.
+func (m model) danglingImages() []imagetypes.Summary {
+	var out []imagetypes.Summary
+	for _, img := range m.images {
+		if isDangling(img) {
+			out = append(out, img)
+		}
+	}
+	return out
+}
+
+// confirmDanglingClean asks before removing every dangling image.
+func (m model) confirmDanglingClean() (tea.Model, tea.Cmd) {
+	images := m.danglingImages()
+	if len(images) == 0 {
+		m.status = "No dangling images."
+		return m, nil
+	}
+	var total int64
+	for _, img := range images {
+		total += img.Size
+	}
+	return m.askConfirm(
+		fmt.Sprintf("Remove %d dangling images (%s)?", len(images), format.HumanizeBytes(total)),
+		confirmChoice{key: "y", label: "remove", status: "Removing dangling images...", cmd: m.backend.removeImages(images)},
+	)
+}
+
+// removeImages removes each image in turn and reports how many were removed
+// and the space reclaimed. Images still used by a container fail and are
+// counted but don't stop the rest.
+func (b *backend) removeImages(images []imagetypes.Summary) tea.Cmd {
+	return b.run(func(ctx context.Context, cli docker.Client) tea.Msg {
+		done := actionDoneMsg{action: "Remove dangling images", reload: resImages}
+
+		var reclaimed int64
+		var firstErr error
+		failed := 0
+		for _, img := range images {
+			start := time.Now()
+			_, err := cli.ImageRemove(ctx, img.ID, imagetypes.RemoveOptions{PruneChildren: true})
+			logCall("ImageRemove", start, err, "id", format.Short12(format.StripSha256(img.ID)))
+			if err != nil {
+				failed++
+				if firstErr == nil {
+					firstErr = fmt.Errorf("%s: %w", format.Short12(format.StripSha256(img.ID)), err)
+				}
+				continue
+			}
+			reclaimed += img.Size
+		}
+
+		removed := len(images) - failed
+		done.status = fmt.Sprintf("Removed %d dangling images, reclaimed %s.", removed, format.HumanizeBytes(reclaimed))
+		if firstErr != nil {
+			done.status = fmt.Sprintf("Removed %d dangling images, reclaimed %s; %d failed (first error: %v).", removed, format.HumanizeBytes(reclaimed), failed, firstErr)
+		}
+		return done
+	})
+}
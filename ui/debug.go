@@ -0,0 +1,34 @@
+package ui
+
+import (
+	"log/slog"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// debugLog receives diagnostic records when --debug is set. Bubbletea owns
+// the terminal, so they go to a file and are discarded otherwise.
+var debugLog = slog.New(slog.DiscardHandler)
+
+// enableDebugLog sends JSON debug records to the file at path.
+func enableDebugLog(path string) (func() error, error) {
+	f, err := tea.LogToFile(path, "superdocker")
+	if err != nil {
+		return nil, err
+	}
+	debugLog = slog.New(slog.NewJSONHandler(f, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	debugLog.Info("debug logging started", "version", VersionString())
+	return f.Close, nil
+}
+
+// logCall records the latency and outcome of the Docker API call that
+// started at start.
+func logCall(call string, start time.Time, err error, attrs ...any) {
+	attrs = append([]any{"call", call, "ms", time.Since(start).Milliseconds()}, attrs...)
+	if err != nil {
+		debugLog.Error("docker api call failed", append(attrs, "err", err)...)
+		return
+	}
+	debugLog.Debug("docker api call", attrs...)
+}
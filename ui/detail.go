@@ -0,0 +1,507 @@
+package ui
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Antityping/superdocker/docker"
+	"github.com/Antityping/superdocker/format"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/docker/docker/api/types/container"
+	imagetypes "github.com/docker/docker/api/types/image"
+	networktypes "github.com/docker/docker/api/types/network"
+	volumetypes "github.com/docker/docker/api/types/volume"
+)
+
+// detailLoadedMsg carries the rendered detail screen for a resource.
+type detailLoadedMsg struct {
+	title string
+	body  string
+	raw   string // pretty-printed inspect JSON, if any
+	err   error
+}
+
+// detailBuilder accumulates the sections of a detail screen.
+type detailBuilder struct {
+	b strings.Builder
+}
+
+func (d *detailBuilder) section(title string) {
+	if d.b.Len() > 0 {
+		d.b.WriteString("\n")
+	}
+	d.b.WriteString(titleStyle.Render(title) + "\n")
+}
+
+func (d *detailBuilder) field(key, value string) {
+	fmt.Fprintf(&d.b, "  %-14s %s\n", key+":", format.OrDash(value))
+}
+
+// list writes one item per line under key, or "-" when empty.
+func (d *detailBuilder) list(key string, items []string) {
+	if len(items) == 0 {
+		d.field(key, "")
+		return
+	}
+	fmt.Fprintf(&d.b, "  %s:\n", key)
+	for _, it := range items {
+		fmt.Fprintf(&d.b, "    %s\n", it)
+	}
+}
+
+func (d *detailBuilder) String() string {
+	return d.b.String()
+}
+
+// openDetail fetches and shows the detail screen for the focused resource.
+func (m model) openDetail() (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	switch m.focusIndex {
+	case 0:
+		if c := m.selectedContainer(); c != nil {
+			cmd = m.backend.containerDetail(c.ID, m.timeFormat)
+		}
+	case 1:
+		if img := m.selectedImage(); img != nil {
+			cmd = m.backend.imageDetail(img.ID, m.timeFormat)
+		}
+	case 2:
+		if v := m.selectedVolume(); v != nil {
+			cmd = m.backend.volumeDetail(v.Name, m.timeFormat)
+		}
+	case 3:
+		if n := m.selectedNetwork(); n != nil {
+			cmd = m.backend.networkDetail(n.ID, m.timeFormat)
+		}
+	case 4:
+		if s := m.selectedService(); s != nil {
+			cmd = serviceDetail(*s, m.timeFormat)
+		}
+	}
+	if cmd == nil {
+		m.status = "Nothing selected."
+		return m, nil
+	}
+	return m.loadDetail(cmd)
+}
+
+// loadDetail switches to an empty detail screen that cmd fills in with a
+// detailLoadedMsg.
+func (m model) loadDetail(cmd tea.Cmd) (tea.Model, tea.Cmd) {
+	m.mode = viewDetail
+	m.topID = ""
+	m.detailTitle = "Loading..."
+	m.detailRaw, m.detailJSON, m.detailStatus = "", false, ""
+	m.detail = viewport.New(m.width, max(m.height-4, 5))
+	m.setDetailBody("")
+	return m, cmd
+}
+
+// setDetailBody replaces the detail content, wrapped to the viewport width.
+func (m *model) setDetailBody(body string) {
+	m.detailBody = body
+	m.refreshDetail()
+}
+
+// refreshDetail shows the formatted body or, when toggled, the raw JSON.
+func (m *model) refreshDetail() {
+	body := m.detailBody
+	if m.detailJSON {
+		body = m.detailRaw
+	}
+	if m.detail.Width > 0 {
+		body = wrapHanging(body, m.detail.Width)
+	}
+	m.detail.SetContent(body)
+}
+
+// inspectJSON pretty-prints an inspect response like `docker inspect`.
+func inspectJSON(v any) string {
+	b, err := json.MarshalIndent(v, "", "    ")
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// fieldPrefix matches the "  Key:   " part of a detail field line.
+var fieldPrefix = regexp.MustCompile(`^ *[^ :]+: +`)
+
+// wrapHanging wraps lines wider than width, indenting continuation lines to
+// the value column of a field so long commands and lists stay readable.
+func wrapHanging(body string, width int) string {
+	lines := strings.Split(body, "\n")
+	out := make([]string, 0, len(lines))
+	for _, l := range lines {
+		if ansi.StringWidth(l) <= width {
+			out = append(out, l)
+			continue
+		}
+		indent := len(fieldPrefix.FindString(l))
+		if indent == 0 {
+			indent = len(l) - len(strings.TrimLeft(l, " "))
+		}
+		if indent > width/2 {
+			out = append(out, ansi.Wrap(l, width, ""))
+			continue
+		}
+		pad := strings.Repeat(" ", indent)
+		for i, w := range strings.Split(ansi.Wrap(l[indent:], width-indent, ""), "\n") {
+			if i == 0 {
+				out = append(out, l[:indent]+w)
+			} else {
+				out = append(out, pad+w)
+			}
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+// resizeDetail fits the detail viewport to the terminal and rewraps its
+// content to the new width.
+func (m *model) resizeDetail() {
+	m.detail.Width = m.width
+	m.detail.Height = max(m.height-4, 5)
+	m.refreshDetail()
+}
+
+// updateDetail handles keys on the detail screen.
+func (m model) updateDetail(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m.quit()
+	case "esc", "q":
+		m.mode = viewDashboard
+		m.pruneArmed = false
+		m.topID = ""
+		return m, nil
+	case "j":
+		if m.detailRaw != "" {
+			m.detailJSON = !m.detailJSON
+			m.refreshDetail()
+			m.detail.GotoTop()
+			return m, nil
+		}
+	case "c":
+		if m.detailJSON {
+			return m, copyToClipboard(m.detailRaw, fmt.Sprintf("Copied %d bytes.", len(m.detailRaw)))
+		}
+	case "y":
+		if m.pruneArmed {
+			m.mode = viewDashboard
+			m.pruneArmed = false
+			return m.confirmRemote(confirmChoice{label: "prune", status: "Pruning...", cmd: m.backend.pruneResources()})
+		}
+	}
+	var cmd tea.Cmd
+	m.detail, cmd = m.detail.Update(msg)
+	return m, cmd
+}
+
+// detailView renders the full-screen detail view.
+func (m model) detailView() string {
+	keys := "esc: back"
+	switch {
+	case m.pruneArmed:
+		keys = "y: prune these • esc: cancel"
+	case m.detailJSON:
+		keys = "j: formatted • c: copy JSON • esc: back"
+	case m.detailRaw != "":
+		keys = "j: JSON • esc: back"
+	}
+	help := helpStyle.Render(fmt.Sprintf("  ↑/↓/PgUp/PgDn: scroll • %s   %3.f%%", keys, m.detail.ScrollPercent()*100))
+	if m.detailStatus != "" {
+		help += statusStyle.Render(m.detailStatus)
+	}
+	return fmt.Sprintf("%s\n%s\n%s", titleStyle.Render(m.detailTitle), m.detail.View(), help)
+}
+
+func (b *backend) containerDetail(id string, tf timeFormat) tea.Cmd {
+	return b.run(func(ctx context.Context, cli docker.Client) tea.Msg {
+		start := time.Now()
+		info, err := cli.ContainerInspect(ctx, id)
+		logCall("ContainerInspect", start, err, "id", format.Short12(id))
+		if err != nil {
+			return detailLoadedMsg{err: err}
+		}
+		return detailLoadedMsg{title: "Container " + strings.TrimPrefix(info.Name, "/"), body: renderContainerDetail(info, tf), raw: inspectJSON(info)}
+	})
+}
+
+func renderContainerDetail(info container.InspectResponse, tf timeFormat) string {
+	var d detailBuilder
+	if info.ContainerJSONBase != nil {
+		d.section("General")
+		d.field("Name", strings.TrimPrefix(info.Name, "/"))
+		d.field("ID", info.ID)
+		d.field("Image", info.Image)
+		d.field("Created", tf.formatString(info.Created))
+		d.field("Platform", info.Platform)
+		d.field("Driver", info.Driver)
+		d.field("Restarts", fmt.Sprintf("%d", info.RestartCount))
+
+		// Path and Args are what the daemon actually ran: the entrypoint
+		// and cmd after image defaults are applied
+		if info.Path != "" {
+			argv := append([]string{info.Path}, info.Args...)
+			d.section("Command")
+			d.field("Command", strings.Join(argv, " "))
+			items := make([]string, len(argv))
+			for i, a := range argv {
+				items[i] = fmt.Sprintf("[%d] %s", i, strconv.Quote(a))
+			}
+			d.list("Argv", items)
+		}
+	}
+
+	if cfg := info.Config; cfg != nil {
+		d.section("Config")
+		d.field("Image", cfg.Image)
+		d.field("Hostname", cfg.Hostname)
+		d.field("User", cfg.User)
+		d.field("WorkingDir", cfg.WorkingDir)
+		d.field("Entrypoint", format.QuoteArgs(cfg.Entrypoint))
+		d.field("Cmd", format.QuoteArgs(cfg.Cmd))
+		d.field("Tty", fmt.Sprintf("%t", cfg.Tty))
+		var exposed []string
+		for p := range cfg.ExposedPorts {
+			exposed = append(exposed, string(p))
+		}
+		sort.Strings(exposed)
+		d.list("Exposed", exposed)
+		d.list("Env", cfg.Env)
+		d.list("Labels", format.SortedKV(cfg.Labels))
+	}
+
+	if info.ContainerJSONBase != nil && info.State != nil {
+		st := info.State
+		d.section("State")
+		d.field("Status", string(st.Status))
+		d.field("Running", fmt.Sprintf("%t", st.Running))
+		d.field("Paused", fmt.Sprintf("%t", st.Paused))
+		d.field("Restarting", fmt.Sprintf("%t", st.Restarting))
+		d.field("OOMKilled", fmt.Sprintf("%t", st.OOMKilled))
+		d.field("Pid", fmt.Sprintf("%d", st.Pid))
+		d.field("ExitCode", fmt.Sprintf("%d", st.ExitCode))
+		d.field("Error", st.Error)
+		d.field("StartedAt", tf.formatString(st.StartedAt))
+		d.field("Uptime", uptimeString(st.Running, st.StartedAt))
+		d.field("FinishedAt", tf.formatString(st.FinishedAt))
+		if st.Health != nil {
+			d.field("Health", fmt.Sprintf("%s (%d failing)", st.Health.Status, st.Health.FailingStreak))
+		}
+	}
+
+	if info.ContainerJSONBase != nil && info.HostConfig != nil {
+		hc := info.HostConfig
+		d.section("Host Config")
+		d.field("Restart", string(hc.RestartPolicy.Name))
+		d.field("NetworkMode", string(hc.NetworkMode))
+		d.field("Privileged", fmt.Sprintf("%t", hc.Privileged))
+		d.field("Memory", limitString(hc.Memory))
+		d.field("CPUs", cpuString(hc.NanoCPUs))
+		var bindings []string
+		for port, bs := range hc.PortBindings {
+			for _, b := range bs {
+				bindings = append(bindings, fmt.Sprintf("%s:%s -> %s", format.OrDash(b.HostIP), b.HostPort, port))
+			}
+		}
+		sort.Strings(bindings)
+		d.list("Ports", bindings)
+	}
+
+	d.section("Mounts")
+	if len(info.Mounts) == 0 {
+		d.b.WriteString("  -\n")
+	}
+	for _, mnt := range info.Mounts {
+		mode := "rw"
+		if !mnt.RW {
+			mode = "ro"
+		}
+		src := mnt.Source
+		if mnt.Name != "" {
+			src = mnt.Name
+		}
+		fmt.Fprintf(&d.b, "  %s %s -> %s (%s)\n", mnt.Type, format.OrDash(src), mnt.Destination, mode)
+	}
+
+	d.section("Networks")
+	if info.NetworkSettings == nil || len(info.NetworkSettings.Networks) == 0 {
+		d.b.WriteString("  -\n")
+	} else {
+		names := make([]string, 0, len(info.NetworkSettings.Networks))
+		for name := range info.NetworkSettings.Networks {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			ep := info.NetworkSettings.Networks[name]
+			if ep == nil {
+				continue
+			}
+			fmt.Fprintf(&d.b, "  %s\n", name)
+			d.field("  IP", ep.IPAddress)
+			d.field("  Gateway", ep.Gateway)
+			d.field("  MAC", ep.MacAddress)
+			d.field("  Aliases", strings.Join(ep.Aliases, ", "))
+		}
+	}
+	return d.String()
+}
+
+func (b *backend) imageDetail(id string, tf timeFormat) tea.Cmd {
+	return b.run(func(ctx context.Context, cli docker.Client) tea.Msg {
+		start := time.Now()
+		info, err := cli.ImageInspect(ctx, id)
+		logCall("ImageInspect", start, err, "id", format.Short12(format.StripSha256(id)))
+		if err != nil {
+			return detailLoadedMsg{err: err}
+		}
+		title := "Image " + format.Short12(format.StripSha256(info.ID))
+		if len(info.RepoTags) > 0 {
+			title = "Image " + info.RepoTags[0]
+		}
+		return detailLoadedMsg{title: title, body: renderImageDetail(info, tf), raw: inspectJSON(info)}
+	})
+}
+
+func renderImageDetail(info imagetypes.InspectResponse, tf timeFormat) string {
+	var d detailBuilder
+	d.section("General")
+	d.field("ID", format.StripSha256(info.ID))
+	d.list("RepoTags", info.RepoTags)
+	d.list("RepoDigests", info.RepoDigests)
+	d.field("Created", tf.formatString(info.Created))
+	d.field("Size", format.HumanizeBytes(info.Size))
+	d.field("Platform", strings.Trim(info.Os+"/"+info.Architecture+"/"+info.Variant, "/"))
+	d.field("Author", info.Author)
+	d.field("Parent", format.Short12(format.StripSha256(info.Parent)))
+
+	if cfg := info.Config; cfg != nil {
+		d.section("Config")
+		d.field("User", cfg.User)
+		d.field("WorkingDir", cfg.WorkingDir)
+		d.field("Entrypoint", format.QuoteArgs(cfg.Entrypoint))
+		d.field("Cmd", format.QuoteArgs(cfg.Cmd))
+		var exposed []string
+		for p := range cfg.ExposedPorts {
+			exposed = append(exposed, p)
+		}
+		sort.Strings(exposed)
+		d.list("Exposed", exposed)
+		d.list("Env", cfg.Env)
+		d.list("Labels", format.SortedKV(cfg.Labels))
+	}
+
+	d.section("Layers")
+	if len(info.RootFS.Layers) == 0 {
+		d.b.WriteString("  -\n")
+	}
+	for i, l := range info.RootFS.Layers {
+		fmt.Fprintf(&d.b, "  %2d %s\n", i+1, l)
+	}
+	return d.String()
+}
+
+func (b *backend) volumeDetail(name string, tf timeFormat) tea.Cmd {
+	return b.run(func(ctx context.Context, cli docker.Client) tea.Msg {
+		start := time.Now()
+		info, err := cli.VolumeInspect(ctx, name)
+		logCall("VolumeInspect", start, err, "name", name)
+		if err != nil {
+			return detailLoadedMsg{err: err}
+		}
+		return detailLoadedMsg{title: "Volume " + info.Name, body: renderVolumeDetail(info, tf), raw: inspectJSON(info)}
+	})
+}
+
+func renderVolumeDetail(v volumetypes.Volume, tf timeFormat) string {
+	var d detailBuilder
+	d.section("General")
+	d.field("Name", v.Name)
+	d.field("Driver", v.Driver)
+	d.field("Scope", v.Scope)
+	d.field("Mountpoint", v.Mountpoint)
+	d.field("Created", tf.formatString(v.CreatedAt))
+	if v.UsageData != nil {
+		d.field("Size", format.HumanizeBytes(v.UsageData.Size))
+		d.field("RefCount", fmt.Sprintf("%d", v.UsageData.RefCount))
+	}
+	d.section("Labels")
+	d.list("Labels", format.SortedKV(v.Labels))
+	d.section("Options")
+	d.list("Options", format.SortedKV(v.Options))
+	return d.String()
+}
+
+func (b *backend) networkDetail(id string, tf timeFormat) tea.Cmd {
+	return b.run(func(ctx context.Context, cli docker.Client) tea.Msg {
+		start := time.Now()
+		info, err := cli.NetworkInspect(ctx, id, networktypes.InspectOptions{})
+		logCall("NetworkInspect", start, err, "id", format.Short12(id))
+		if err != nil {
+			return detailLoadedMsg{err: err}
+		}
+		return detailLoadedMsg{title: "Network " + info.Name, body: renderNetworkDetail(info, tf), raw: inspectJSON(info)}
+	})
+}
+
+func renderNetworkDetail(n networktypes.Inspect, tf timeFormat) string {
+	var d detailBuilder
+	d.section("General")
+	d.field("Name", n.Name)
+	d.field("ID", n.ID)
+	d.field("Driver", n.Driver)
+	d.field("Scope", n.Scope)
+	d.field("Created", tf.format(n.Created))
+	d.field("Internal", fmt.Sprintf("%t", n.Internal))
+	d.field("Attachable", fmt.Sprintf("%t", n.Attachable))
+	d.field("Ingress", fmt.Sprintf("%t", n.Ingress))
+	d.field("IPv6", fmt.Sprintf("%t", n.EnableIPv6))
+
+	d.section("IPAM")
+	d.field("Driver", n.IPAM.Driver)
+	var subnets []string
+	for _, c := range n.IPAM.Config {
+		subnets = append(subnets, fmt.Sprintf("%s gateway %s", format.OrDash(c.Subnet), format.OrDash(c.Gateway)))
+	}
+	d.list("Subnets", subnets)
+
+	d.section("Options")
+	relevant, rest := splitDriverOptions(n.Driver, n.Options)
+	for _, l := range relevant {
+		label, value, _ := strings.Cut(l, ": ")
+		d.field(label, value)
+	}
+	d.list("Options", rest)
+	d.list("Labels", format.SortedKV(n.Labels))
+
+	d.section("Containers")
+	d.b.WriteString(strings.TrimPrefix(renderEndpoints(n.Containers), "Containers:") + "\n")
+	return d.String()
+}
+
+// limitString renders a memory limit, where 0 means unlimited.
+func limitString(n int64) string {
+	if n == 0 {
+		return "unlimited"
+	}
+	return format.HumanizeBytes(n)
+}
+
+// cpuString renders a NanoCPUs limit as a CPU count, where 0 means unlimited.
+func cpuString(n int64) string {
+	if n == 0 {
+		return "unlimited"
+	}
+	return fmt.Sprintf("%.2f", float64(n)/1e9)
+}
@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Antityping/superdocker/docker"
+	"github.com/Antityping/superdocker/format"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/docker/docker/api/types/container"
+)
+
+// diffStyles color the A/C/D marker of each filesystem change.
+var diffStyles = map[container.ChangeType]lipgloss.Style{
+	container.ChangeAdd:    lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("42")),
+	container.ChangeModify: lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("214")),
+	container.ChangeDelete: lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("203")),
+}
+
+// openDiff shows the filesystem changes of the selected container.
+func (m model) openDiff() (tea.Model, tea.Cmd) {
+	c := m.selectedContainer()
+	if c == nil {
+		m.status = "No container selected."
+		return m, nil
+	}
+	return m.loadDetail(m.backend.containerDiff(c.ID, containerName(*c)))
+}
+
+// containerDiff lists what changed in a container's filesystem since it
+// was created, like `docker diff`.
+func (b *backend) containerDiff(id, name string) tea.Cmd {
+	return b.run(func(ctx context.Context, cli docker.Client) tea.Msg {
+		start := time.Now()
+		changes, err := cli.ContainerDiff(ctx, id)
+		logCall("ContainerDiff", start, err, "id", format.Short12(id))
+		if err != nil {
+			return detailLoadedMsg{err: err}
+		}
+		title := fmt.Sprintf("Diff %s (%d changes)", name, len(changes))
+		return detailLoadedMsg{title: title, body: renderDiff(changes)}
+	})
+}
+
+// renderDiff renders one change per line, sorted by path, with counts per
+// kind at the top.
+func renderDiff(changes []container.FilesystemChange) string {
+	if len(changes) == 0 {
+		return "  No changes since the container was created.\n"
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	counts := map[container.ChangeType]int{}
+	var b strings.Builder
+	for _, ch := range changes {
+		counts[ch.Kind]++
+	}
+	fmt.Fprintf(&b, "  %d added • %d changed • %d deleted\n\n",
+		counts[container.ChangeAdd], counts[container.ChangeModify], counts[container.ChangeDelete])
+	for _, ch := range changes {
+		fmt.Fprintf(&b, "  %s %s\n", diffStyles[ch.Kind].Render(ch.Kind.String()), ch.Path)
+	}
+	return b.String()
+}
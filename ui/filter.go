@@ -0,0 +1,158 @@
+package ui
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/sahilm/fuzzy"
+)
+
+// filterTerm is one whitespace-separated term of the `/` filter. Plain terms
+// match as case-insensitive substrings of a resource's fields, or as fuzzy
+// subsequences in fuzzy mode; `label:key` and `label:key=value` terms match
+// its labels. `port:N` and `port:N/proto` terms match a container's
+// published or exposed ports; the other tables have no ports and ignore
+// them.
+type filterTerm struct {
+	text       string
+	label      bool
+	labelKey   string
+	labelValue string
+	hasValue   bool
+	port       bool
+	portNum    uint16
+	portProto  string // "" matches any protocol
+}
+
+// resourceFilter is the parsed `/` filter. All terms must match.
+type resourceFilter struct {
+	terms []filterTerm
+	fuzzy bool
+}
+
+// parseFilter splits a filter expression into terms.
+func parseFilter(s string, fuzzy bool) resourceFilter {
+	f := resourceFilter{fuzzy: fuzzy}
+	for _, word := range strings.Fields(s) {
+		if rest, ok := strings.CutPrefix(word, "label:"); ok && rest != "" {
+			t := filterTerm{label: true, labelKey: rest}
+			if k, v, ok := strings.Cut(rest, "="); ok {
+				t.labelKey, t.labelValue, t.hasValue = k, v, true
+			}
+			f.terms = append(f.terms, t)
+			continue
+		}
+		if rest, ok := strings.CutPrefix(word, "port:"); ok {
+			num, proto, _ := strings.Cut(rest, "/")
+			// Anything but a port number is searched for as plain text
+			if n, err := strconv.ParseUint(num, 10, 16); err == nil {
+				f.terms = append(f.terms, filterTerm{port: true, portNum: uint16(n), portProto: strings.ToLower(proto)})
+				continue
+			}
+		}
+		f.terms = append(f.terms, filterTerm{text: strings.ToLower(word)})
+	}
+	return f
+}
+
+// match reports whether a resource with the given labels and searchable
+// fields satisfies every term.
+func (f resourceFilter) match(labels map[string]string, fields ...string) bool {
+	_, ok := f.score(labels, fields...)
+	return ok
+}
+
+// score is like match but also rates how well the resource matches: the sum
+// of each plain term's best fuzzy score across fields. Exact matches score 0.
+func (f resourceFilter) score(labels map[string]string, fields ...string) (int, bool) {
+	total := 0
+	for _, t := range f.terms {
+		if t.port {
+			continue // see matchPorts
+		}
+		if t.label {
+			v, ok := labels[t.labelKey]
+			if !ok || (t.hasValue && v != t.labelValue) {
+				return 0, false
+			}
+			continue
+		}
+		if f.fuzzy {
+			matches := fuzzy.Find(t.text, fields)
+			if len(matches) == 0 {
+				return 0, false
+			}
+			total += matches[0].Score
+			continue
+		}
+		found := false
+		for _, field := range fields {
+			if strings.Contains(strings.ToLower(field), t.text) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return 0, false
+		}
+	}
+	return total, true
+}
+
+// matchPorts reports whether ports satisfy every port term, each matching
+// a published host port or an exposed container port.
+func (f resourceFilter) matchPorts(ports []container.Port) bool {
+	for _, t := range f.terms {
+		if !t.port {
+			continue
+		}
+		found := false
+		for _, p := range ports {
+			if (p.PublicPort == t.portNum || p.PrivatePort == t.portNum) && (t.portProto == "" || p.Type == t.portProto) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// filterItems returns the items matching f, best matches first in fuzzy
+// mode. fields returns an item's labels and searchable fields.
+func filterItems[T any](f resourceFilter, items []T, fields func(T) (map[string]string, []string)) []T {
+	type scored struct {
+		item  T
+		score int
+	}
+	var matched []scored
+	for _, it := range items {
+		labels, fs := fields(it)
+		if s, ok := f.score(labels, fs...); ok {
+			matched = append(matched, scored{it, s})
+		}
+	}
+	if f.fuzzy {
+		sort.SliceStable(matched, func(i, j int) bool { return matched[i].score > matched[j].score })
+	}
+	out := make([]T, len(matched))
+	for i, s := range matched {
+		out[i] = s.item
+	}
+	return out
+}
+
+// setFilter applies a new `/` filter to every table. An empty value clears it.
+func (m *model) setFilter(value string) {
+	m.filterText = strings.TrimSpace(value)
+	m.filter = parseFilter(m.filterText, m.fuzzyFilter)
+	m.setContainerRows()
+	m.setImageRows()
+	m.setVolumeRows()
+	m.setNetworkRows()
+	m.setServiceRows()
+}
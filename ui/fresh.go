@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// freshFor is how long rows added by a reload stay marked as new.
+const freshFor = 3 * time.Second
+
+// freshMarker flags containers and images that appeared in the last reload.
+// Cells are measured as plain text, so the marker is a character rather
+// than a color.
+const freshMarker = "+"
+
+// freshExpiredMsg fires when the newest rows should lose their marker.
+type freshExpiredMsg struct{}
+
+// markFresh records the containers and images in a new load that the
+// previous load did not have. It must run before the index is rebuilt, and
+// marks nothing on the first load.
+func (m *model) markFresh(msg dataLoadedMsg) tea.Cmd {
+	if m.index[0] == nil {
+		return nil
+	}
+	now := time.Now()
+	added := false
+	for _, c := range msg.containers {
+		if _, ok := m.index[0][c.ID]; !ok {
+			m.fresh[c.ID] = now
+			added = true
+		}
+	}
+	for _, img := range msg.images {
+		if _, ok := m.index[1][img.ID]; !ok {
+			m.fresh[img.ID] = now
+			added = true
+		}
+	}
+	if !added {
+		return nil
+	}
+	return tea.Tick(freshFor, func(time.Time) tea.Msg { return freshExpiredMsg{} })
+}
+
+// expireFresh drops markers older than freshFor, reporting whether any
+// were dropped.
+func (m *model) expireFresh() bool {
+	dropped := false
+	for id, t := range m.fresh {
+		if time.Since(t) >= freshFor {
+			delete(m.fresh, id)
+			dropped = true
+		}
+	}
+	return dropped
+}
@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Antityping/superdocker/docker"
+	"github.com/Antityping/superdocker/format"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/docker/docker/api/types/system"
+)
+
+// hostStyle is summaryStyle dimmed, so the host line stays in the
+// background.
+var hostStyle = summaryStyle.Foreground(lipgloss.Color("241"))
+
+type hostInfoMsg struct {
+	info system.Info
+	err  error
+}
+
+// loadHostInfo asks the daemon about itself and the host it runs on.
+func (b *backend) loadHostInfo() tea.Cmd {
+	return b.run(func(ctx context.Context, cli docker.Client) tea.Msg {
+		start := time.Now()
+		info, err := cli.Info(ctx)
+		logCall("Info", start, err)
+		return hostInfoMsg{info: info, err: err}
+	})
+}
+
+// hostView renders the one-line daemon overview shown below the tables,
+// truncated to the terminal width, or "" until it has loaded.
+func (m model) hostView() string {
+	if m.hostInfo == nil {
+		return ""
+	}
+	i := m.hostInfo
+	line := fmt.Sprintf("%s • Docker %s • %s (%s/%s) • %d CPUs, %s • %d running / %d paused / %d stopped containers • %d images",
+		format.OrDash(i.Name), format.OrDash(i.ServerVersion), format.OrDash(i.OperatingSystem), i.OSType, i.Architecture,
+		i.NCPU, format.HumanizeBytes(i.MemTotal),
+		i.ContainersRunning, i.ContainersPaused, i.ContainersStopped, i.Images,
+	)
+	if m.width > 0 {
+		line = format.TrimTo(line, m.width-2)
+	}
+	return hostStyle.Render(line)
+}
@@ -0,0 +1,93 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/Antityping/superdocker/format"
+)
+
+// fullIDWidth fits an untruncated 64-character hex ID.
+const fullIDWidth = 64
+
+// idColumns is the index of the ID column per table, or -1 when it has none.
+var idColumns = [5]int{0, 1, -1, 1, -1}
+
+// tableID renders an ID for a table cell, short unless full IDs are on.
+func (m model) tableID(id string) string {
+	id = format.StripSha256(id)
+	if m.fullIDs {
+		return id
+	}
+	return format.Short12(id)
+}
+
+// setFullIDs switches the ID columns between short and full IDs, widening
+// them to fit, and saves the choice.
+func (m *model) setFullIDs(on bool) {
+	m.fullIDs = on
+	m.prefs.FullIDs = on
+	for i, col := range idColumns {
+		if col < 0 {
+			continue
+		}
+		m.columns[i][col].width = idColumnWidth(on)
+		m.applyColumns(i)
+	}
+	m.setContainerRows()
+	m.setImageRows()
+	m.setNetworkRows()
+	m.status = "Short IDs."
+	if on {
+		m.status = "Full IDs."
+	}
+	if err := savePrefs(m.prefs); err != nil {
+		m.status = fmt.Sprintf("Could not save preferences: %v", err)
+	}
+}
+
+// setHideInfo hides or shows the info panel and saves the choice.
+func (m *model) setHideInfo(on bool) {
+	m.layout.hideInfo = on
+	m.prefs.HideInfo = on
+	m.resizeTables()
+	m.status = "Info panel shown."
+	if on {
+		m.status = "Info panel hidden; h shows it."
+	}
+	if err := savePrefs(m.prefs); err != nil {
+		m.status = fmt.Sprintf("Could not save preferences: %v", err)
+	}
+}
+
+// idColumnWidth is the width of an ID column.
+func idColumnWidth(full bool) int {
+	if full {
+		return fullIDWidth
+	}
+	return 12
+}
+
+// paneWidths splits the terminal between the tables and the info panel.
+// Full IDs widen the tables past the configured split when there is room,
+// so the other columns are not pushed out of view.
+func (m model) paneWidths() (int, int) {
+	lw, rw := computeColumnsWidth(m.width, m.layout)
+	if !m.fullIDs || m.layout.stacked(m.width) || m.layout.hideInfo {
+		return lw, rw
+	}
+	need := 0
+	for i := range m.columns {
+		w := 2 // border
+		for _, c := range m.columns[i] {
+			if !c.hidden {
+				w += c.width + 2 // cell padding
+			}
+		}
+		need = max(need, w)
+	}
+	if need > lw {
+		lw = max(lw, min(need, m.width-m.layout.minInfoWidth))
+		rw = m.width - lw
+	}
+	return lw, rw
+}
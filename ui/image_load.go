@@ -0,0 +1,156 @@
+package ui
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Antityping/superdocker/docker"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/jsonmessage"
+)
+
+// imageLoadStream holds the open response of an in-flight image load.
+type imageLoadStream struct {
+	file   *os.File
+	body   io.ReadCloser
+	json   bool
+	dec    *json.Decoder
+	lines  *bufio.Scanner
+	loaded []string
+}
+
+func (s *imageLoadStream) close() {
+	s.body.Close()
+	s.file.Close()
+}
+
+type imageLoadProgressMsg struct {
+	stream *imageLoadStream
+	line   string
+}
+
+type imageLoadDoneMsg struct {
+	loaded []string
+	err    error
+}
+
+// validateTarPath checks that path names a readable regular file.
+func validateTarPath(path string) (string, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return "", errors.New("no path given")
+	}
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, path[2:])
+		}
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	if fi.IsDir() {
+		return "", fmt.Errorf("%s is a directory", path)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	f.Close()
+	return path, nil
+}
+
+// startImageLoad sends the tar at path to the daemon and returns the first
+// progress message of the response stream.
+func (b *backend) startImageLoad(path string) tea.Cmd {
+	return b.run(func(ctx context.Context, cli docker.Client) tea.Msg {
+		f, err := os.Open(path)
+		if err != nil {
+			return imageLoadDoneMsg{err: err}
+		}
+		start := time.Now()
+		resp, err := cli.ImageLoad(ctx, f, client.ImageLoadWithQuiet(false))
+		logCall("ImageLoad", start, err)
+		if err != nil {
+			f.Close()
+			return imageLoadDoneMsg{err: err}
+		}
+		s := &imageLoadStream{file: f, body: resp.Body, json: resp.JSON}
+		if resp.JSON {
+			s.dec = json.NewDecoder(resp.Body)
+		} else {
+			s.lines = bufio.NewScanner(resp.Body)
+		}
+		return readImageLoadStream(s)
+	})
+}
+
+// readImageLoad reads the next message from the load response stream. The
+// stream was opened with the root context, so quitting aborts the read.
+func (b *backend) readImageLoad(s *imageLoadStream) tea.Cmd {
+	return b.run(func(context.Context, docker.Client) tea.Msg {
+		return readImageLoadStream(s)
+	})
+}
+
+// readImageLoadStream returns the next progress line, or the final result
+// once the stream ends.
+func readImageLoadStream(s *imageLoadStream) tea.Msg {
+	for {
+		line, err := s.next()
+		if err == io.EOF {
+			s.close()
+			return imageLoadDoneMsg{loaded: s.loaded}
+		}
+		if err != nil {
+			s.close()
+			return imageLoadDoneMsg{err: err}
+		}
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "Loaded image") {
+			s.loaded = append(s.loaded, line)
+		}
+		return imageLoadProgressMsg{stream: s, line: line}
+	}
+}
+
+// next returns one line of progress, decoding the JSON message stream when
+// the daemon sends one.
+func (s *imageLoadStream) next() (string, error) {
+	if !s.json {
+		if s.lines.Scan() {
+			return strings.TrimSpace(s.lines.Text()), nil
+		}
+		if err := s.lines.Err(); err != nil {
+			return "", err
+		}
+		return "", io.EOF
+	}
+
+	var jm jsonmessage.JSONMessage
+	if err := s.dec.Decode(&jm); err != nil {
+		return "", err
+	}
+	if jm.Error != nil {
+		return "", jm.Error
+	}
+	switch {
+	case jm.Stream != "":
+		return strings.TrimSpace(jm.Stream), nil
+	case jm.Progress != nil:
+		return strings.TrimSpace(fmt.Sprintf("%s %s", jm.Status, jm.Progress.String())), nil
+	default:
+		return strings.TrimSpace(jm.Status), nil
+	}
+}
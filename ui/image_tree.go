@@ -0,0 +1,167 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Antityping/superdocker/format"
+	"github.com/charmbracelet/bubbles/table"
+	imagetypes "github.com/docker/docker/api/types/image"
+)
+
+// treeHeaderPrefix marks repository group rows in the image tree view.
+const treeHeaderPrefix = "▾ "
+
+// repoKeyPrefix marks the row keys of repository header rows. Image IDs
+// start with "sha256:", so the keys never collide and the cursor stays on
+// a header across refreshes like on any other row.
+const repoKeyPrefix = "repo:"
+
+// imageGroup is one repository and the tagged images under it.
+type imageGroup struct {
+	repo string
+	tags []imageTag
+}
+
+type imageTag struct {
+	tag string
+	img *imagetypes.Summary
+}
+
+// splitRepoTag splits "registry:5000/name:tag" into repository and tag. The
+// tag separator is the last colon after the last slash.
+func splitRepoTag(ref string) (string, string) {
+	i := strings.LastIndex(ref, ":")
+	if i < 0 || i < strings.LastIndex(ref, "/") {
+		return ref, ""
+	}
+	return ref[:i], ref[i+1:]
+}
+
+// imageGroups groups the loaded images by repository, sorted by name, with
+// untagged images collected under "<none>".
+func (m model) imageGroups() []imageGroup {
+	byRepo := map[string]*imageGroup{}
+	var order []string
+	add := func(repo, tag string, img *imagetypes.Summary) {
+		g, ok := byRepo[repo]
+		if !ok {
+			g = &imageGroup{repo: repo}
+			byRepo[repo] = g
+			order = append(order, repo)
+		}
+		g.tags = append(g.tags, imageTag{tag: tag, img: img})
+	}
+	for i := range m.images {
+		img := &m.images[i]
+		if !m.filter.match(img.Labels, append([]string{img.ID}, img.RepoTags...)...) {
+			continue
+		}
+		tagged := false
+		for _, rt := range img.RepoTags {
+			if rt == "<none>:<none>" {
+				continue
+			}
+			repo, tag := splitRepoTag(rt)
+			add(repo, tag, img)
+			tagged = true
+		}
+		if !tagged {
+			add("<none>", "<none>", img)
+		}
+	}
+	sort.Strings(order)
+	groups := make([]imageGroup, 0, len(order))
+	for _, repo := range order {
+		g := byRepo[repo]
+		sort.SliceStable(g.tags, func(i, j int) bool { return g.tags[i].tag < g.tags[j].tag })
+		groups = append(groups, *g)
+	}
+	return groups
+}
+
+// imageTreeRows renders the image groups as an indented tree, returning the
+// rows and their keys: the image ID, or the repository for header rows.
+func (m model) imageTreeRows() ([]table.Row, []string) {
+	rows := []table.Row{}
+	keys := []string{}
+	used := m.usedImageIDs()
+	for _, g := range m.imageGroups() {
+		var total int64
+		seen := map[string]bool{}
+		for _, t := range g.tags {
+			if !seen[t.img.ID] {
+				seen[t.img.ID] = true
+				total += t.img.Size
+			}
+		}
+		header := fmt.Sprintf("%s%s (%d)", treeHeaderPrefix, g.repo, len(g.tags))
+		rows = append(rows, table.Row{header, "", format.HumanizeBytes(total), ""})
+		keys = append(keys, repoKeyPrefix+g.repo)
+		for i, t := range g.tags {
+			branch := "├─ "
+			if i == len(g.tags)-1 {
+				branch = "└─ "
+			}
+			tag := t.tag
+			if _, ok := m.fresh[t.img.ID]; ok {
+				tag = freshMarker + " " + tag
+			}
+			if isDangling(*t.img) && !used[t.img.ID] {
+				tag += " " + unusedMarker
+			}
+			imgID := m.tableID(t.img.ID)
+			sizeMB := fmt.Sprintf("%.1fMB", float64(t.img.Size)/1024.0/1024.0)
+			rows = append(rows, table.Row{"  " + branch + tag, imgID, sizeMB, ageCell(unixTime(t.img.Created))})
+			keys = append(keys, t.img.ID)
+		}
+	}
+	return rows, keys
+}
+
+// selectedRepo returns the repository of the selected header row in the
+// image tree view.
+func (m model) selectedRepo() (string, bool) {
+	if !m.imageTree {
+		return "", false
+	}
+	return strings.CutPrefix(m.selectedRowKey(1), repoKeyPrefix)
+}
+
+// renderImageGroupInfo summarizes a repository group in the info panel.
+func (m model) renderImageGroupInfo(repo string) string {
+	for _, g := range m.imageGroups() {
+		if g.repo != repo {
+			continue
+		}
+		var total int64
+		ids := map[string]bool{}
+		tags := make([]string, 0, len(g.tags))
+		for _, t := range g.tags {
+			tags = append(tags, t.tag)
+			if !ids[t.img.ID] {
+				ids[t.img.ID] = true
+				total += t.img.Size
+			}
+		}
+		return fmt.Sprintf("Repository: %s\nTags: %s\nImages: %d\nTotal size: %s",
+			g.repo, strings.Join(tags, ", "), len(ids), format.HumanizeBytes(total),
+		)
+	}
+	return "No image selected."
+}
+
+// imageParent describes an image's parent, preferring a tag of the parent
+// when it is loaded. ParentID is only set for locally built images.
+func (m model) imageParent(img imagetypes.Summary) string {
+	if img.ParentID == "" {
+		return "-"
+	}
+	for _, p := range m.images {
+		if p.ID == img.ParentID && len(p.RepoTags) > 0 {
+			return p.RepoTags[0]
+		}
+	}
+	return format.Short12(format.StripSha256(img.ParentID))
+}
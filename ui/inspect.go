@@ -0,0 +1,92 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Antityping/superdocker/docker"
+	"github.com/Antityping/superdocker/format"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/docker/docker/api/types/container"
+	networktypes "github.com/docker/docker/api/types/network"
+)
+
+type containerInspectedMsg struct {
+	id   string
+	info container.InspectResponse
+	err  error
+}
+
+type networkInspectedMsg struct {
+	id   string
+	info networktypes.Inspect
+	err  error
+}
+
+// inspectContainer fetches the full inspect document for a container.
+func (b *backend) inspectContainer(id string) tea.Cmd {
+	return b.run(func(ctx context.Context, cli docker.Client) tea.Msg {
+		start := time.Now()
+		info, err := cli.ContainerInspect(ctx, id)
+		logCall("ContainerInspect", start, err, "id", format.Short12(id))
+		return containerInspectedMsg{id: id, info: info, err: err}
+	})
+}
+
+// selectionKey identifies the focused table and its selected row.
+func (m model) selectionKey() string {
+	return fmt.Sprintf("%d:%s", m.focusIndex, m.selectedRowKey(m.focusIndex))
+}
+
+// fetchSelectionDetails returns a command loading the details the info
+// panel shows for the selected resource beyond the list data, or nil when
+// they are cached or the resource type has none.
+func (m *model) fetchSelectionDetails() tea.Cmd {
+	switch m.focusIndex {
+	case 0:
+		return m.inspectSelectedContainer()
+	case 3:
+		return m.inspectSelectedNetwork()
+	}
+	return nil
+}
+
+// inspectSelectedContainer returns a command fetching inspect data for the
+// selected container, or nil when it is already cached or in flight. A zero
+// entry marks the fetch as pending.
+func (m *model) inspectSelectedContainer() tea.Cmd {
+	c := m.selectedContainer()
+	if c == nil {
+		return nil
+	}
+	if _, ok := m.inspected[c.ID]; ok {
+		return nil
+	}
+	m.inspected[c.ID] = container.InspectResponse{}
+	return m.backend.inspectContainer(c.ID)
+}
+
+// inspectNetwork fetches the full inspect document, including attached
+// endpoints, for a network.
+func (b *backend) inspectNetwork(id string) tea.Cmd {
+	return b.run(func(ctx context.Context, cli docker.Client) tea.Msg {
+		start := time.Now()
+		info, err := cli.NetworkInspect(ctx, id, networktypes.InspectOptions{})
+		logCall("NetworkInspect", start, err, "id", format.Short12(id))
+		return networkInspectedMsg{id: id, info: info, err: err}
+	})
+}
+
+// inspectSelectedNetwork is the network counterpart of inspectSelectedContainer.
+func (m *model) inspectSelectedNetwork() tea.Cmd {
+	nw := m.selectedNetwork()
+	if nw == nil {
+		return nil
+	}
+	if _, ok := m.networkInspected[nw.ID]; ok {
+		return nil
+	}
+	m.networkInspected[nw.ID] = networktypes.Inspect{}
+	return m.backend.inspectNetwork(nw.ID)
+}
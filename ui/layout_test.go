@@ -0,0 +1,37 @@
+package ui
+
+import "testing"
+
+func TestComputeColumnsWidth(t *testing.T) {
+	// 30/70 with 10-column minimums on both sides
+	split := layoutConfig{splitRatio: 0.3, minTableWidth: 10, minInfoWidth: 10}
+	hidden := split
+	hidden.hideInfo = true
+	tests := []struct {
+		name         string
+		total        int
+		cfg          layoutConfig
+		wantL, wantR int
+	}{
+		{"30/70 at 100", 100, split, 30, 70},
+		{"30/70 at 200", 200, split, 60, 140},
+		{"30/70 at the narrowest side by side", 80, split, 24, 56},
+		{"split rounds", 85, split, 26, 59},
+		{"stacked below narrowWidth", 79, split, 79, 79},
+		{"stacked at zero", 0, split, 0, 0},
+		{"table minimum", 100, layoutConfig{splitRatio: 0.05, minTableWidth: 10, minInfoWidth: 10}, 10, 90},
+		{"info minimum", 100, layoutConfig{splitRatio: 0.95, minTableWidth: 10, minInfoWidth: 10}, 90, 10},
+		{"stacked below wider minimums", 100, layoutConfig{splitRatio: 0.3, minTableWidth: 60, minInfoWidth: 50}, 100, 100},
+		{"hidden info panel", 100, hidden, 100, 0},
+		{"default layout", 100, defaultLayout(), 30, 70},
+		{"default layout caps the info panel", 300, defaultLayout(), 180, 120},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, r := computeColumnsWidth(tt.total, tt.cfg)
+			if l != tt.wantL || r != tt.wantR {
+				t.Errorf("computeColumnsWidth(%d) = %d, %d; want %d, %d", tt.total, l, r, tt.wantL, tt.wantR)
+			}
+		})
+	}
+}
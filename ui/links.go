@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/Antityping/superdocker/format"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// imageName names an image by its first tag, or its short ID when untagged.
+func (m model) imageName(id string) string {
+	if i, ok := m.index[1][id]; ok && i < len(m.images) {
+		for _, rt := range m.images[i].RepoTags {
+			if rt != "<none>:<none>" {
+				return rt
+			}
+		}
+	}
+	return format.Short12(format.StripSha256(id))
+}
+
+// showImageUsers limits the containers table to the containers created from
+// the selected image and focuses it.
+func (m model) showImageUsers() (tea.Model, tea.Cmd) {
+	img := m.selectedImage()
+	if img == nil {
+		m.status = "No image selected."
+		return m, nil
+	}
+	m.usersOf = img.ID
+	m.setContainerRows()
+	m.focusTable(0)
+	m.status = fmt.Sprintf("%d containers use %s • u: show all", len(m.imageUsers(img.ID)), m.imageName(img.ID))
+	return m, nil
+}
+
+// clearImageUsers lifts the limit set by showImageUsers.
+func (m model) clearImageUsers() (tea.Model, tea.Cmd) {
+	m.usersOf = ""
+	m.setContainerRows()
+	m.status = "Showing all containers."
+	return m, nil
+}
+
+// jumpToImage selects the selected container's image in the images table.
+func (m model) jumpToImage() (tea.Model, tea.Cmd) {
+	c := m.selectedContainer()
+	if c == nil {
+		m.status = "No container selected."
+		return m, nil
+	}
+	if !m.moveCursorTo(1, c.ImageID) {
+		m.status = fmt.Sprintf("Image of %s is not listed; it may be filtered out or removed.", containerName(*c))
+		return m, nil
+	}
+	m.focusTable(1)
+	m.status = ""
+	return m, nil
+}
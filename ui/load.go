@@ -0,0 +1,301 @@
+package ui
+
+import (
+	"context"
+	"math/bits"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Antityping/superdocker/docker"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	imagetypes "github.com/docker/docker/api/types/image"
+	networktypes "github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/swarm"
+	volumetypes "github.com/docker/docker/api/types/volume"
+)
+
+// resourceSet selects which resource lists a load fetches.
+type resourceSet uint8
+
+const (
+	resContainers resourceSet = 1 << iota
+	resImages
+	resVolumes
+	resNetworks
+	// resServices are the Swarm services, listed only on Swarm managers
+	// and so left out of allResources
+	resServices
+
+	allResources = resContainers | resImages | resVolumes | resNetworks
+)
+
+// String names the resources in s, e.g. "images, volumes".
+func (s resourceSet) String() string {
+	var names []string
+	for i, name := range tableNames {
+		if s&(resourceSet(1)<<i) != 0 {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// loadData lists containers, images, volumes and networks.
+func (b *backend) loadData() tea.Cmd {
+	return b.load(allResources)
+}
+
+// loadContainers re-lists only the containers, e.g. after a stop.
+func (b *backend) loadContainers() tea.Cmd {
+	return b.load(resContainers)
+}
+
+// loadImages re-lists only the images, e.g. after a removal or load.
+func (b *backend) loadImages() tea.Cmd {
+	return b.load(resImages)
+}
+
+// load lists the resources in kinds. The zero set means all of them, so
+// an action that doesn't say what it touched refreshes everything.
+func (b *backend) load(kinds resourceSet) tea.Cmd {
+	if kinds == 0 {
+		kinds = allResources
+	}
+	return b.run(func(ctx context.Context, cli docker.Client) tea.Msg {
+		return listResources(ctx, cli, kinds, b.listFilterSet())
+	})
+}
+
+// stageLoadedMsg carries one list of the staged first load.
+type stageLoadedMsg struct {
+	dataLoadedMsg
+}
+
+// loadStaged lists each resource in its own command, so the loading screen
+// can show every list arriving from a slow daemon rather than one message
+// for the whole load.
+func (b *backend) loadStaged() tea.Cmd {
+	cmds := make([]tea.Cmd, 0, len(tableNames))
+	for i := range tableNames {
+		kind := resourceSet(1) << i
+		if allResources&kind == 0 {
+			continue
+		}
+		cmds = append(cmds, b.run(func(ctx context.Context, cli docker.Client) tea.Msg {
+			return stageLoadedMsg{listResources(ctx, cli, kind, b.listFilterSet())}
+		}))
+	}
+	return tea.Batch(cmds...)
+}
+
+// addStage merges one list of the staged first load into m.staged. A
+// failed list counts as arrived and is recorded in m.staged.failed, so the
+// merged load reports it beside the lists that did arrive.
+func (m *model) addStage(msg stageLoadedMsg) {
+	m.stages |= msg.kinds | msg.failed
+	m.staged.kinds |= msg.kinds
+	m.staged.failed |= msg.failed
+	if msg.err != nil && m.staged.err == nil {
+		m.staged.err = msg.err
+	}
+	switch msg.kinds {
+	case resContainers:
+		m.staged.containers = msg.containers
+	case resImages:
+		m.staged.images = msg.images
+	case resVolumes:
+		m.staged.volumes = msg.volumes
+	case resNetworks:
+		m.staged.networks = msg.networks
+	}
+}
+
+// loadingProgress reports which lists of the first load have arrived, e.g.
+// "Loading containers... done • Loading images...".
+func (m model) loadingProgress() string {
+	parts := make([]string, 0, len(tableNames))
+	for i, name := range tableNames {
+		kind := resourceSet(1) << i
+		if allResources&kind == 0 {
+			continue
+		}
+		part := "Loading " + name + "..."
+		switch {
+		case m.staged.failed&kind != 0:
+			part += " failed"
+		case m.stages&kind != 0:
+			part += " done"
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, " • ")
+}
+
+// listResources runs the list calls for kinds concurrently, so a load
+// from a remote daemon takes one round trip rather than four. A failed
+// list leaves the others usable: msg.kinds holds the lists that arrived,
+// msg.failed the others, and msg.err the first failure in table order.
+// Each call sends its table's daemon-side filter.
+func listResources(ctx context.Context, cli docker.Client, kinds resourceSet, filter [len(tableNames)]filters.Args) dataLoadedMsg {
+	var msg dataLoadedMsg
+	var errs [len(tableNames)]error
+	var wg sync.WaitGroup
+	list := func(kind resourceSet, fn func() error) {
+		if kinds&kind == 0 {
+			return
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[bits.TrailingZeros8(uint8(kind))] = fn()
+		}()
+	}
+
+	list(resContainers, func() (err error) {
+		start := time.Now()
+		msg.containers, err = cli.ContainerList(ctx, container.ListOptions{All: true, Filters: filter[0]})
+		logCall("ContainerList", start, err, "count", len(msg.containers))
+		return err
+	})
+
+	list(resImages, func() (err error) {
+		start := time.Now()
+		// Manifests are only filled in by daemons using the containerd image
+		// store; older daemons ignore the option
+		msg.images, err = cli.ImageList(ctx, imagetypes.ListOptions{Manifests: true, Filters: filter[1]})
+		logCall("ImageList", start, err, "count", len(msg.images))
+		return err
+	})
+
+	list(resVolumes, func() error {
+		start := time.Now()
+		vresp, err := cli.VolumeList(ctx, volumetypes.ListOptions{Filters: filter[2]})
+		logCall("VolumeList", start, err, "count", len(vresp.Volumes))
+		if err != nil {
+			return err
+		}
+		msg.volumes = make([]volumetypes.Volume, 0, len(vresp.Volumes))
+		for _, v := range vresp.Volumes {
+			if v != nil {
+				msg.volumes = append(msg.volumes, *v)
+			}
+		}
+		return nil
+	})
+
+	list(resNetworks, func() (err error) {
+		start := time.Now()
+		msg.networks, err = cli.NetworkList(ctx, networktypes.ListOptions{Filters: filter[3]})
+		logCall("NetworkList", start, err, "count", len(msg.networks))
+		return err
+	})
+
+	list(resServices, func() (err error) {
+		start := time.Now()
+		msg.services, err = cli.ServiceList(ctx, swarm.ServiceListOptions{Status: true, Filters: filter[4]})
+		logCall("ServiceList", start, err, "count", len(msg.services))
+		return err
+	})
+
+	wg.Wait()
+	for i, err := range errs {
+		kind := resourceSet(1) << i
+		switch {
+		case kinds&kind == 0:
+		case err != nil:
+			msg.failed |= kind
+			if msg.err == nil {
+				msg.err = err
+			}
+		default:
+			msg.kinds |= kind
+		}
+	}
+	return msg
+}
+
+// pruneContainerCaches drops the inspect data, log tails and checkpoints
+// of containers that are gone from the reloaded list cs or changed state
+// since the last one, so the info panel keeps what is still current
+// instead of refetching on every refresh.
+func (m *model) pruneContainerCaches(cs []container.Summary) {
+	was := make(map[string]string, len(m.containers))
+	for _, c := range m.containers {
+		was[c.ID] = c.State
+	}
+	current := make(map[string]bool, len(cs))
+	for _, c := range cs {
+		if state, ok := was[c.ID]; ok && state == c.State {
+			current[c.ID] = true
+		}
+	}
+	for id := range m.inspected {
+		if !current[id] {
+			m.forgetContainer(id)
+		}
+	}
+	for id := range m.logTails {
+		if !current[id] {
+			m.forgetContainer(id)
+		}
+	}
+	for id := range m.checkpoints {
+		if !current[id] {
+			m.forgetContainer(id)
+		}
+	}
+}
+
+// forgetContainer drops what the info panel fetched for one container, so
+// the next selection fetches it again, e.g. after an action changed it.
+func (m *model) forgetContainer(id string) {
+	delete(m.inspected, id)
+	delete(m.logTails, id)
+	delete(m.checkpoints, id)
+}
+
+// applyLoaded replaces the loaded lists in msg and rebuilds the tables
+// that show them. The images table also depends on the containers, which
+// decide which images are in use.
+func (m *model) applyLoaded(msg dataLoadedMsg) {
+	m.loaded |= msg.kinds
+	if msg.kinds&resContainers != 0 {
+		m.pruneContainerCaches(msg.containers)
+		m.containers = msg.containers
+	}
+	if msg.kinds&resImages != 0 {
+		m.images = msg.images
+	}
+	if msg.kinds&resVolumes != 0 {
+		m.volumes = msg.volumes
+	}
+	if msg.kinds&resNetworks != 0 {
+		m.networks = msg.networks
+	}
+	if msg.kinds&resServices != 0 {
+		m.services = msg.services
+	}
+	m.buildIndex()
+
+	if msg.kinds&resContainers != 0 {
+		m.trackRestarts(msg.containers)
+		m.setContainerRows()
+	}
+	if msg.kinds&(resContainers|resImages) != 0 {
+		m.setImageRows()
+	}
+	if msg.kinds&resVolumes != 0 {
+		m.setVolumeRows()
+	}
+	if msg.kinds&(resContainers|resNetworks) != 0 {
+		// Network inspect lists the attached containers
+		m.setNetworkRows()
+		m.networkInspected = map[string]networktypes.Inspect{}
+	}
+	if msg.kinds&resServices != 0 {
+		m.setServiceRows()
+	}
+}
@@ -0,0 +1,132 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Antityping/superdocker/docker/dockertest"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	imagetypes "github.com/docker/docker/api/types/image"
+	networktypes "github.com/docker/docker/api/types/network"
+	volumetypes "github.com/docker/docker/api/types/volume"
+)
+
+// newFake returns a fake daemon with one of each resource.
+func newFake() *dockertest.Fake {
+	return &dockertest.Fake{
+		Containers: []container.Summary{
+			{ID: "c1aaaaaaaaaaaaaaaa", Names: []string{"/web"}, Image: "nginx:latest", ImageID: "sha256:i1", State: "running", Status: "Up 2 minutes"},
+			{ID: "c2bbbbbbbbbbbbbbbb", Names: []string{"/db"}, Image: "postgres:16", ImageID: "sha256:i2", State: "exited", Status: "Exited (0) 1 hour ago"},
+		},
+		Images: []imagetypes.Summary{
+			{ID: "sha256:i1", RepoTags: []string{"nginx:latest"}, Size: 1 << 20},
+			{ID: "sha256:i2", RepoTags: []string{"postgres:16"}, Size: 2 << 20},
+		},
+		Volumes:  []*volumetypes.Volume{{Name: "data", Driver: "local"}, nil},
+		Networks: []networktypes.Summary{{ID: "n1cccccccccccccccc", Name: "backend", Driver: "bridge"}},
+	}
+}
+
+// newTestBackend returns a backend calling cli.
+func newTestBackend(cli *dockertest.Fake) *backend {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &backend{ctx: ctx, cancel: cancel, cli: cli}
+}
+
+func noFilters() [len(tableNames)]filters.Args {
+	var set [len(tableNames)]filters.Args
+	for i := range set {
+		set[i] = filters.NewArgs()
+	}
+	return set
+}
+
+func TestListResources(t *testing.T) {
+	fake := newFake()
+	msg := listResources(context.Background(), fake, allResources, noFilters())
+	if msg.err != nil || msg.kinds != allResources || msg.failed != 0 {
+		t.Fatalf("kinds %v, failed %v, err %v; want every list", msg.kinds, msg.failed, msg.err)
+	}
+	if len(msg.containers) != 2 || len(msg.images) != 2 || len(msg.networks) != 1 {
+		t.Errorf("got %d containers, %d images, %d networks; want 2, 2, 1", len(msg.containers), len(msg.images), len(msg.networks))
+	}
+	if len(msg.volumes) != 1 || msg.volumes[0].Name != "data" {
+		t.Errorf("volumes = %v; want the one non-nil volume", msg.volumes)
+	}
+	if slices.Contains(fake.Calls(), "ServiceList") {
+		t.Error("listed services without being asked to")
+	}
+}
+
+func TestListResourcesPartialFailure(t *testing.T) {
+	fake := newFake()
+	boom := errors.New("boom")
+	fake.Errs = map[string]error{"ImageList": boom}
+	msg := listResources(context.Background(), fake, allResources, noFilters())
+	if msg.failed != resImages || !errors.Is(msg.err, boom) {
+		t.Errorf("failed %v, err %v; want images failing with boom", msg.failed, msg.err)
+	}
+	if want := resContainers | resVolumes | resNetworks; msg.kinds != want {
+		t.Errorf("kinds = %v; want %v", msg.kinds, want)
+	}
+	if len(msg.containers) != 2 {
+		t.Errorf("got %d containers; want the list despite the images failing", len(msg.containers))
+	}
+}
+
+func TestApplyLoadedEmptyFields(t *testing.T) {
+	fake := newFake()
+	// Just created: no name, image, command or status yet
+	fake.Containers = []container.Summary{{ID: "c3eeeeeeeeeeeeeeee", Names: nil, State: "created"}}
+	msg := listResources(context.Background(), fake, resContainers, noFilters())
+	if msg.err != nil || len(msg.containers) != 1 {
+		t.Fatalf("got %d containers, err %v; want the one container", len(msg.containers), msg.err)
+	}
+
+	m := send(t, newTestModel(t, fake), msg)
+	rows := m.containersTable.Rows()
+	if len(rows) != 1 {
+		t.Fatalf("got %d rows; want 1", len(rows))
+	}
+	if !slices.Contains(rows[0], "<c3eeeeeeeeee>") {
+		t.Errorf("row = %q; want the name derived from the ID", rows[0])
+	}
+	// The last column is the busy marker, blank when idle
+	for _, cell := range rows[0][:len(rows[0])-1] {
+		if strings.TrimSpace(cell) == "" {
+			t.Errorf("row = %q; want a placeholder in every empty cell", rows[0])
+			break
+		}
+	}
+	info := ansi.Strip(m.renderSelectedContainerInfo())
+	for _, want := range []string{"Name: <c3eeeeeeeeee>", "Image: -", "Command: -", "Status: -"} {
+		if !strings.Contains(info, want) {
+			t.Errorf("info panel lacks %q:\n%s", want, info)
+		}
+	}
+}
+
+func TestContainerAction(t *testing.T) {
+	fake := newFake()
+	b := newTestBackend(fake)
+	defer b.stop()
+
+	done, ok := b.containerAction("c1aaaaaaaaaaaaaaaa", "web", "Stop", "stopped", stopContainer)().(actionDoneMsg)
+	if !ok || done.err != nil || done.status != "web stopped." || done.reload != resContainers {
+		t.Fatalf("got %+v; want a successful stop reloading the containers", done)
+	}
+	if calls := fake.Calls(); !slices.Equal(calls, []string{"ContainerStop c1aaaaaaaaaaaaaaaa"}) {
+		t.Errorf("calls = %v", calls)
+	}
+
+	fake.Errs = map[string]error{"ContainerStart": errors.New("no such container")}
+	done = b.containerAction("c2bbbbbbbbbbbbbbbb", "db", "Start", "started", startContainer)().(actionDoneMsg)
+	if done.err == nil || done.status != "" {
+		t.Errorf("got %+v; want the start error", done)
+	}
+}
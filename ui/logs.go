@@ -0,0 +1,98 @@
+package ui
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Antityping/superdocker/docker"
+	"github.com/Antityping/superdocker/format"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// logTailLines is how many recent log lines the info panel shows.
+const logTailLines = 5
+
+type logTailMsg struct {
+	id    string
+	lines []string
+	err   error
+}
+
+// fetchLogTail reads the last few log lines of a container. Non-TTY
+// containers multiplex stdout and stderr, so their stream is demuxed first.
+func (b *backend) fetchLogTail(id string, tty bool) tea.Cmd {
+	return b.run(func(ctx context.Context, cli docker.Client) tea.Msg {
+		start := time.Now()
+		rc, err := cli.ContainerLogs(ctx, id, container.LogsOptions{
+			ShowStdout: true,
+			ShowStderr: true,
+			Tail:       strconv.Itoa(logTailLines),
+		})
+		logCall("ContainerLogs", start, err, "id", format.Short12(id))
+		if err != nil {
+			return logTailMsg{id: id, err: err}
+		}
+		defer rc.Close()
+
+		var buf bytes.Buffer
+		if tty {
+			_, err = io.Copy(&buf, rc)
+		} else {
+			_, err = stdcopy.StdCopy(&buf, &buf, rc)
+		}
+		if err != nil {
+			return logTailMsg{id: id, err: err}
+		}
+		return logTailMsg{id: id, lines: splitLogLines(buf.String())}
+	})
+}
+
+// splitLogLines splits log output into lines, dropping the trailing newline
+// and carriage returns left by TTY output.
+func splitLogLines(s string) []string {
+	s = strings.TrimRight(s, "\n")
+	if s == "" {
+		return []string{}
+	}
+	lines := strings.Split(s, "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimRight(l, "\r")
+	}
+	if len(lines) > logTailLines {
+		lines = lines[len(lines)-logTailLines:]
+	}
+	return lines
+}
+
+// logTailFor returns a command fetching the log tail for a container once
+// its inspect data is known, or nil when cached or in flight.
+func (m *model) logTailFor(info container.InspectResponse) tea.Cmd {
+	if _, ok := m.logTails[info.ID]; ok {
+		return nil
+	}
+	m.logTails[info.ID] = nil
+	tty := info.Config != nil && info.Config.Tty
+	return m.backend.fetchLogTail(info.ID, tty)
+}
+
+// renderLogTail renders the cached log tail for the info panel.
+func (m model) renderLogTail(id string, width int) string {
+	lines, ok := m.logTails[id]
+	switch {
+	case !ok || lines == nil:
+		return "Logs: loading..."
+	case len(lines) == 0:
+		return "Logs: -"
+	}
+	out := make([]string, len(lines))
+	for i, l := range lines {
+		out[i] = "  " + format.TrimTo(l, width)
+	}
+	return "Logs:\n" + strings.Join(out, "\n")
+}
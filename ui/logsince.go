@@ -0,0 +1,150 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// logWindow limits the history the log viewer loads before following new
+// output: what was logged since a point in time, of which at most tail
+// lines.
+type logWindow struct {
+	since time.Time // zero for the whole history
+	label string    // the since value as typed, e.g. "10m"
+	tail  string    // a line count, or "all"
+}
+
+// defaultLogWindow is the recent backlog the log viewer opens with.
+var defaultLogWindow = logWindow{tail: logBacklog}
+
+// sinceLayouts are the absolute times the since prompt accepts, in local
+// time unless they carry a zone.
+var sinceLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// parseLogWindow parses the since prompt: a duration back from now such as
+// "10m" or "1h30m", or a time such as "2024-05-01 10:00" or "15:04"
+// (today), optionally followed by the most lines to load, e.g. "5m 200".
+// Without a line count everything since then is loaded, so "5m" is
+// precisely the last five minutes; a line count alone loads that many of
+// the most recent lines. Empty input restores the default backlog.
+func parseLogWindow(s string, now time.Time) (logWindow, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return defaultLogWindow, nil
+	}
+	w := logWindow{tail: "all"}
+	last := fields[len(fields)-1]
+	if lines, err := strconv.Atoi(last); err == nil {
+		if lines <= 0 {
+			return logWindow{}, errors.New("the line count must be positive")
+		}
+		w.tail = last
+		fields = fields[:len(fields)-1]
+	}
+	if len(fields) == 0 {
+		return w, nil
+	}
+	w.label = strings.Join(fields, " ")
+	since, err := parseSince(w.label, now)
+	if err != nil {
+		return logWindow{}, err
+	}
+	w.since = since
+	return w, nil
+}
+
+// parseSince parses the since part of the since prompt.
+func parseSince(s string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		if d <= 0 {
+			return time.Time{}, errors.New("the duration must be positive")
+		}
+		return now.Add(-d), nil
+	}
+	var t time.Time
+	var err error
+	for _, layout := range sinceLayouts {
+		if t, err = time.ParseInLocation(layout, s, now.Location()); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		// A time of day means today
+		for _, layout := range []string{"15:04:05", "15:04"} {
+			var tod time.Time
+			if tod, err = time.Parse(layout, s); err == nil {
+				y, mo, d := now.Date()
+				t = time.Date(y, mo, d, tod.Hour(), tod.Minute(), tod.Second(), 0, now.Location())
+				break
+			}
+		}
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is neither a duration like 10m nor a time like 2024-05-01 10:00 or 15:04", s)
+	}
+	if t.After(now) {
+		return time.Time{}, fmt.Errorf("%s is in the future", t.Format("2006-01-02 15:04:05"))
+	}
+	return t, nil
+}
+
+// describe renders w for the log view title, e.g. "since 10m, last 200",
+// or "" for the default backlog.
+func (w logWindow) describe() string {
+	if w == defaultLogWindow {
+		return ""
+	}
+	var parts []string
+	if w.label != "" {
+		parts = append(parts, "since "+w.label)
+	}
+	if w.tail != "all" {
+		parts = append(parts, "last "+w.tail)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// prompt renders w back as since prompt input.
+func (w logWindow) prompt() string {
+	if w == defaultLogWindow {
+		return ""
+	}
+	return strings.TrimSpace(strings.TrimSuffix(w.label+" "+w.tail, " all"))
+}
+
+// openLogSincePrompt asks for the window of history the log view loads.
+func (m model) openLogSincePrompt() (tea.Model, tea.Cmd) {
+	return m.openPrompt(promptLogSince, "Logs since: ", m.logs.window.prompt())
+}
+
+// logSincePreview renders the line under the since prompt: the start time
+// the input means, or why it can't be used.
+func (m model) logSincePreview() string {
+	w, err := parseLogWindow(m.input.Value(), time.Now())
+	switch {
+	case err != nil:
+		return exitErrorStyle.Render("  ✗ " + err.Error())
+	case w == defaultLogWindow:
+		return helpStyle.Render("  e.g. 10m, 1h30m, 2024-05-01 10:00, 15:04, optionally then a line count • empty: last " + logBacklog + " lines")
+	}
+	desc := "last " + w.tail + " lines"
+	if !w.since.IsZero() {
+		desc = "from " + w.since.Format("2006-01-02 15:04:05")
+		if w.tail != "all" {
+			desc += ", at most " + w.tail + " lines"
+		}
+	}
+	return helpStyle.Render("  " + desc + "   enter: reload • esc: cancel")
+}
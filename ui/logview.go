@@ -0,0 +1,415 @@
+package ui
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/Antityping/superdocker/docker"
+	"github.com/Antityping/superdocker/format"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+const (
+	// logBacklog is how many existing lines the log viewer starts with.
+	logBacklog = "500"
+	// logBufferLines caps the lines the log viewer retains; older ones
+	// are dropped as new ones arrive.
+	logBufferLines = 5000
+	// logBatchLines caps the lines delivered in one message, so a burst
+	// of output doesn't stall the UI.
+	logBatchLines = 500
+)
+
+var (
+	logMatchStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("16")).
+			Background(lipgloss.Color("229"))
+
+	logCurrentMatchStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("16")).
+				Background(lipgloss.Color("214"))
+)
+
+// logStream is an open, followed log stream of one container. A goroutine
+// splits it into lines; err is set before lines is closed.
+type logStream struct {
+	lines  chan string
+	err    error
+	cancel context.CancelFunc
+}
+
+type logLinesMsg struct {
+	stream *logStream
+	lines  []string
+	done   bool // the stream ended; stream.err says why, if not EOF
+}
+
+// logViewer is the state of the full-screen log view.
+type logViewer struct {
+	stream  *logStream
+	id      string
+	name    string
+	tty     bool
+	window  logWindow // the history loaded before following
+	lines   []string
+	ended   string // why the stream stopped, once it has
+	query   string
+	filter  bool  // show only lines matching query
+	matches []int // indexes into lines of the lines matching query
+	cur     int   // index into matches of the current match, -1 for none
+	vp      viewport.Model
+}
+
+// openLogs shows the selected container's logs, following new output.
+func (m model) openLogs() (tea.Model, tea.Cmd) {
+	c := m.selectedContainer()
+	if c == nil {
+		m.status = "No container selected."
+		return m, nil
+	}
+	tty := false
+	if info, ok := m.inspected[c.ID]; ok && info.Config != nil {
+		tty = info.Config.Tty
+	}
+	m.mode = viewLogs
+	m.logs = &logViewer{
+		id:     c.ID,
+		name:   containerName(*c),
+		tty:    tty,
+		window: defaultLogWindow,
+		vp:     viewport.New(m.width, max(m.height-4, 5)),
+	}
+	return m, m.restartLogs()
+}
+
+// restartLogs (re)opens the log stream of the log view with its window,
+// dropping the lines already shown.
+func (m *model) restartLogs() tea.Cmd {
+	v := m.logs
+	if v.stream != nil {
+		v.stream.cancel()
+	}
+	ctx, cancel := context.WithCancel(m.backend.ctx)
+	v.stream = &logStream{lines: make(chan string, logBatchLines), cancel: cancel}
+	v.lines, v.ended = nil, ""
+	v.search(v.query)
+	v.render()
+	return m.backend.followLogs(ctx, v.stream, v.id, v.tty, v.window)
+}
+
+// closeLogs stops the log stream and returns to the dashboard.
+func (m model) closeLogs() model {
+	m.logs.stream.cancel()
+	m.logs = nil
+	m.mode = viewDashboard
+	return m
+}
+
+// followLogs opens the container's log stream, starting with the history
+// in w, and returns its first lines. Non-TTY containers multiplex stdout
+// and stderr, so their stream is demuxed first.
+func (b *backend) followLogs(ctx context.Context, s *logStream, id string, tty bool, w logWindow) tea.Cmd {
+	return b.run(func(_ context.Context, cli docker.Client) tea.Msg {
+		opts := container.LogsOptions{
+			ShowStdout: true,
+			ShowStderr: true,
+			Follow:     true,
+			Tail:       w.tail,
+		}
+		if !w.since.IsZero() {
+			opts.Since = strconv.FormatInt(w.since.Unix(), 10)
+		}
+		start := time.Now()
+		rc, err := cli.ContainerLogs(ctx, id, opts)
+		logCall("ContainerLogs", start, err, "id", format.Short12(id), "follow", true, "since", opts.Since, "tail", opts.Tail)
+		if err != nil {
+			s.err = err
+			close(s.lines)
+			return logLinesMsg{stream: s, done: true}
+		}
+		go scanLogs(ctx, s, rc, tty)
+		return readLogStream(ctx, s)
+	})
+}
+
+// scanLogs feeds the lines of rc to s until rc ends or ctx is canceled.
+func scanLogs(ctx context.Context, s *logStream, rc io.ReadCloser, tty bool) {
+	defer close(s.lines)
+	defer rc.Close()
+	var r io.Reader = rc
+	if !tty {
+		pr, pw := io.Pipe()
+		go func() {
+			_, err := stdcopy.StdCopy(pw, pw, rc)
+			pw.CloseWithError(err)
+		}()
+		defer pr.Close()
+		r = pr
+	}
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	for sc.Scan() {
+		select {
+		case s.lines <- strings.TrimRight(sc.Text(), "\r"):
+		case <-ctx.Done():
+			return
+		}
+	}
+	if ctx.Err() == nil {
+		s.err = sc.Err()
+	}
+}
+
+// readLogs waits for the next lines of an open log stream.
+func (b *backend) readLogs(s *logStream) tea.Cmd {
+	return b.run(func(ctx context.Context, _ docker.Client) tea.Msg {
+		return readLogStream(ctx, s)
+	})
+}
+
+// readLogStream blocks for one line, then takes whatever else is already
+// buffered, up to logBatchLines.
+func readLogStream(ctx context.Context, s *logStream) tea.Msg {
+	var first string
+	var ok bool
+	select {
+	case first, ok = <-s.lines:
+	case <-ctx.Done():
+		return nil
+	}
+	if !ok {
+		return logLinesMsg{stream: s, done: true}
+	}
+	lines := []string{first}
+	for len(lines) < logBatchLines {
+		select {
+		case l, ok := <-s.lines:
+			if !ok {
+				return logLinesMsg{stream: s, lines: lines, done: true}
+			}
+			lines = append(lines, l)
+		default:
+			return logLinesMsg{stream: s, lines: lines}
+		}
+	}
+	return logLinesMsg{stream: s, lines: lines}
+}
+
+// addLogLines appends the lines of msg to the open log view, or reports
+// false when msg belongs to a stream that has since been closed.
+func (m *model) addLogLines(msg logLinesMsg) bool {
+	v := m.logs
+	if v == nil || v.stream != msg.stream {
+		return false
+	}
+	follow := v.vp.AtBottom()
+	v.lines = append(v.lines, msg.lines...)
+	if over := len(v.lines) - logBufferLines; over > 0 {
+		v.lines = append(v.lines[:0:0], v.lines[over:]...)
+		// Keep the current match on its line as the indexes shift
+		if v.cur >= 0 {
+			v.matches[v.cur] -= over
+		}
+	}
+	if msg.done {
+		v.ended = "stream ended"
+		if err := msg.stream.err; err != nil {
+			v.ended = "stream failed: " + err.Error()
+		}
+	}
+	v.search(v.query)
+	v.render()
+	if follow {
+		v.vp.GotoBottom()
+	}
+	return true
+}
+
+// search finds the lines matching query, keeping the current match on the
+// same line when it still matches. Matching ignores case unless query
+// has upper-case letters.
+func (v *logViewer) search(query string) {
+	current := -1
+	if v.query == query && v.cur >= 0 && v.cur < len(v.matches) {
+		current = v.matches[v.cur]
+	}
+	v.query = query
+	v.matches = v.matches[:0]
+	v.cur = -1
+	if query == "" {
+		return
+	}
+	for i, l := range v.lines {
+		if matchIndex(l, query) >= 0 {
+			if i == current || (v.cur < 0 && current < 0) {
+				v.cur = len(v.matches)
+			}
+			v.matches = append(v.matches, i)
+		}
+	}
+}
+
+// matchIndex returns the byte offset of query in line, or -1, with the
+// smart-case rule of search.
+func matchIndex(line, query string) int {
+	if strings.IndexFunc(query, unicode.IsUpper) < 0 {
+		if lower := strings.ToLower(line); len(lower) == len(line) {
+			return strings.Index(lower, strings.ToLower(query))
+		}
+	}
+	return strings.Index(line, query)
+}
+
+// highlight renders every match of query in line with style.
+func highlight(line, query string, style lipgloss.Style) string {
+	var b strings.Builder
+	for {
+		i := matchIndex(line, query)
+		if i < 0 {
+			break
+		}
+		b.WriteString(line[:i])
+		b.WriteString(style.Render(line[i : i+len(query)]))
+		line = line[i+len(query):]
+	}
+	b.WriteString(line)
+	return b.String()
+}
+
+// render fills the viewport with the retained lines, or only the matching
+// ones in filter mode, with matches highlighted.
+func (v *logViewer) render() {
+	current := -1
+	if v.cur >= 0 {
+		current = v.matches[v.cur]
+	}
+	var out []string
+	add := func(i int) {
+		l := v.lines[i]
+		switch {
+		case v.query == "":
+		case i == current:
+			l = highlight(l, v.query, logCurrentMatchStyle)
+		default:
+			l = highlight(l, v.query, logMatchStyle)
+		}
+		out = append(out, l)
+	}
+	if v.filter && v.query != "" {
+		out = make([]string, 0, len(v.matches))
+		for _, i := range v.matches {
+			add(i)
+		}
+	} else {
+		out = make([]string, 0, len(v.lines))
+		for i := range v.lines {
+			add(i)
+		}
+	}
+	v.vp.SetContent(strings.Join(out, "\n"))
+}
+
+// jump moves the current match by delta, wrapping around, and scrolls it
+// into the middle of the viewport.
+func (v *logViewer) jump(delta int) {
+	if len(v.matches) == 0 {
+		return
+	}
+	if v.cur < 0 {
+		v.cur = 0
+	} else {
+		v.cur = (v.cur + delta + len(v.matches)) % len(v.matches)
+	}
+	v.render()
+	row := v.matches[v.cur]
+	if v.filter {
+		row = v.cur
+	}
+	v.vp.SetYOffset(row - v.vp.Height/2)
+}
+
+// resize fits the log viewport to the terminal.
+func (v *logViewer) resize(width, height int) {
+	v.vp.Width = width
+	v.vp.Height = max(height-4, 5)
+	v.render()
+}
+
+// updateLogs handles keys on the log view.
+func (m model) updateLogs(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	v := m.logs
+	switch msg.String() {
+	case "ctrl+c":
+		return m.quit()
+	case "esc", "q":
+		return m.closeLogs(), nil
+	case "/":
+		return m.openPrompt(promptLogSearch, "Search logs: ", v.query)
+	case "s":
+		return m.openLogSincePrompt()
+	case "n":
+		v.jump(1)
+		return m, nil
+	case "N":
+		v.jump(-1)
+		return m, nil
+	case "f":
+		if v.query != "" {
+			v.filter = !v.filter
+			v.render()
+			v.jump(0)
+		}
+		return m, nil
+	case "G", "end":
+		v.vp.GotoBottom()
+		return m, nil
+	}
+	var cmd tea.Cmd
+	v.vp, cmd = v.vp.Update(msg)
+	return m, cmd
+}
+
+// logsView renders the full-screen log view.
+func (m model) logsView() string {
+	v := m.logs
+	state := "following"
+	if v.ended != "" {
+		state = v.ended
+	}
+	if window := v.window.describe(); window != "" {
+		state = window + ", " + state
+	}
+	title := fmt.Sprintf("Logs %s (%d lines, %s)", v.name, len(v.lines), state)
+
+	keys := "/: search • s: since • esc: back"
+	if v.query != "" {
+		mode := "f: only matches"
+		if v.filter {
+			mode = "f: all lines"
+		}
+		keys = "/: search • n/N: next/prev • " + mode + " • s: since • esc: back"
+	}
+	help := helpStyle.Render(fmt.Sprintf("  ↑/↓/PgUp/PgDn: scroll • G: follow • %s   %3.f%%", keys, v.vp.ScrollPercent()*100))
+	footer := help
+	switch {
+	case m.prompt == promptLogSearch:
+		footer = promptStyle.Render(m.input.View())
+	case m.prompt == promptLogSince:
+		footer = promptStyle.Render(m.input.View()) + "\n" + m.logSincePreview()
+	case v.query != "" && len(v.matches) == 0:
+		footer += statusStyle.Render(fmt.Sprintf("no matches for %q", v.query))
+	case v.query != "":
+		footer += statusStyle.Render(fmt.Sprintf("match %d/%d", v.cur+1, len(v.matches)))
+	}
+	return fmt.Sprintf("%s\n%s\n%s", titleStyle.Render(title), v.vp.View(), footer)
+}
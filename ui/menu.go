@@ -0,0 +1,230 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Antityping/superdocker/docker"
+	"github.com/Antityping/superdocker/format"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/docker/docker/api/types/container"
+	imagetypes "github.com/docker/docker/api/types/image"
+)
+
+var menuStyle = lipgloss.NewStyle().
+	BorderStyle(lipgloss.RoundedBorder()).
+	BorderForeground(lipgloss.Color("170")).
+	Padding(0, 1)
+
+// menuItem is one entry of the actions menu.
+type menuItem struct {
+	label    string
+	key      string // the dashboard key doing the same, shown as a hint
+	mutates  bool   // changes the daemon's state, so read-only mode disables it
+	disabled string // why the item can't be chosen now, or ""
+	run      func(model) (tea.Model, tea.Cmd)
+}
+
+// actionMenu lists the actions for the selected resource, for users who
+// don't know the single-key shortcuts yet.
+type actionMenu struct {
+	title  string
+	items  []menuItem
+	cursor int
+}
+
+// openMenu shows the actions menu for the focused table's selection.
+func (m model) openMenu() (tea.Model, tea.Cmd) {
+	var menu *actionMenu
+	switch m.focusIndex {
+	case 0:
+		if c := m.selectedContainer(); c != nil {
+			menu = m.containerMenu(*c)
+		}
+	case 1:
+		if img := m.selectedImage(); img != nil {
+			menu = m.imageMenu(*img)
+		}
+	case 2:
+		if v := m.selectedVolume(); v != nil {
+			menu = &actionMenu{title: v.Name, items: []menuItem{detailsItem}}
+		}
+	case 3:
+		if n := m.selectedNetwork(); n != nil {
+			menu = &actionMenu{title: n.Name, items: []menuItem{detailsItem}}
+		}
+	case 4:
+		if s := m.selectedService(); s != nil {
+			menu = &actionMenu{title: s.Spec.Name, items: []menuItem{detailsItem}}
+		}
+	}
+	if menu == nil {
+		m.status = "Nothing selected."
+		return m, nil
+	}
+	if m.readOnly {
+		for i := range menu.items {
+			if menu.items[i].mutates {
+				menu.items[i].disabled = readOnlyStatus
+			}
+		}
+	}
+	m.menu = menu
+	m.status = ""
+	return m, nil
+}
+
+var detailsItem = menuItem{label: "Details", key: "enter", run: model.openDetail}
+
+// containerMenu lists the actions for c, disabling those its state rules
+// out.
+func (m model) containerMenu(c container.Summary) *actionMenu {
+	name := containerName(c)
+	running := c.State == "running"
+	notRunning := ""
+	if !running {
+		notRunning = "not running"
+	}
+	start := ""
+	if !stoppedStates[c.State] {
+		start = "not stopped"
+	}
+	stopFirst := ""
+	if running {
+		stopFirst = "running; stop it first or use Force remove"
+	}
+	lifecycle := func(action, doing, past string, fn func(context.Context, docker.Client, string) error) func(model) (tea.Model, tea.Cmd) {
+		return func(m model) (tea.Model, tea.Cmd) {
+			return m.confirmRemote(confirmChoice{
+				label:  strings.ToLower(action) + " " + name,
+				status: fmt.Sprintf("%s %s...", doing, name),
+				id:     c.ID,
+				cmd:    m.backend.containerAction(c.ID, name, action, past, fn),
+			})
+		}
+	}
+	items := []menuItem{
+		{label: "Start", mutates: true, disabled: start, run: lifecycle("Start", "Starting", "started", startContainer)},
+		{label: "Stop", mutates: true, disabled: notRunning, run: lifecycle("Stop", "Stopping", "stopped", stopContainer)},
+		{label: "Restart", mutates: true, disabled: notRunning, run: lifecycle("Restart", "Restarting", "restarted", restartContainer)},
+		{label: "Logs", key: "l", run: model.openLogs},
+		{label: "Attach", key: "O", mutates: true, disabled: notRunning, run: model.confirmAttach},
+		detailsItem,
+		{label: "Copy IP", key: "b", run: model.copyIP},
+		{label: "Rename", key: "n", mutates: true, run: model.openRenamePrompt},
+		{label: "Remove", key: "d", mutates: true, disabled: stopFirst, run: func(m model) (tea.Model, tea.Cmd) {
+			return m.confirmRemoveContainer(false)
+		}},
+		{label: "Force remove", key: "D", mutates: true, run: func(m model) (tea.Model, tea.Cmd) {
+			return m.confirmRemoveContainer(true)
+		}},
+	}
+	return &actionMenu{title: name, items: append(items, m.checkpointMenuItems(c)...)}
+}
+
+// imageMenu lists the actions for img.
+func (m model) imageMenu(img imagetypes.Summary) *actionMenu {
+	name := m.imageName(img.ID)
+	inUse := ""
+	if img.Containers > 0 || m.usedImageIDs()[img.ID] {
+		inUse = "in use by a container"
+	}
+	items := []menuItem{
+		detailsItem,
+		{label: "Containers using it", key: "u", run: model.showImageUsers},
+		{label: "Run", key: "R", mutates: true, run: model.openRunForm},
+		{label: "Tag", key: "n", mutates: true, run: model.openTagPrompt},
+		{label: "Remove", mutates: true, disabled: inUse, run: func(m model) (tea.Model, tea.Cmd) {
+			return m.askConfirm(fmt.Sprintf("Remove image %s?", name), confirmChoice{
+				key: "y", label: "remove",
+				status: fmt.Sprintf("Removing %s...", name),
+				cmd:    m.backend.removeImage(img.ID, name),
+			})
+		}},
+	}
+	if m.scoutAvailable {
+		items = append(items, menuItem{label: "Scan vulnerabilities", key: "V", run: model.startScan})
+	}
+	return &actionMenu{title: name, items: items}
+}
+
+// updateMenu handles keys while the actions menu is open.
+func (m model) updateMenu(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	menu := *m.menu
+	switch msg.String() {
+	case "ctrl+c":
+		return m.quit()
+	case "up", "k":
+		menu.cursor = (menu.cursor + len(menu.items) - 1) % len(menu.items)
+	case "down", "j":
+		menu.cursor = (menu.cursor + 1) % len(menu.items)
+	case "enter":
+		item := menu.items[menu.cursor]
+		if item.disabled != "" {
+			m.status = fmt.Sprintf("%s: %s.", item.label, item.disabled)
+			return m, nil
+		}
+		m.menu = nil
+		return item.run(m)
+	default:
+		m.menu = nil
+		return m, nil
+	}
+	m.menu = &menu
+	return m, nil
+}
+
+// menuView renders the open actions menu as a small box above the footer.
+func (m model) menuView() string {
+	lines := []string{titleStyle.Padding(0).Render(m.menu.title)}
+	for i, it := range m.menu.items {
+		line := it.label
+		if it.key != "" {
+			line += helpStyle.Render("  (" + it.key + ")")
+		}
+		switch {
+		case i == m.menu.cursor:
+			line = columnCursorStyle.Render("▸ " + it.label)
+		case it.disabled != "":
+			line = disabledHelpStyle.Render("  " + it.label)
+		default:
+			line = "  " + line
+		}
+		lines = append(lines, line)
+	}
+	lines = append(lines, helpStyle.Render("↑/↓: move • enter: run • esc: close"))
+	return menuStyle.Render(strings.Join(lines, "\n"))
+}
+
+// containerAction runs fn for one container and reports the outcome, e.g.
+// "web stopped.".
+func (b *backend) containerAction(id, name, action, past string, fn func(context.Context, docker.Client, string) error) tea.Cmd {
+	return b.run(func(ctx context.Context, cli docker.Client) tea.Msg {
+		done := actionDoneMsg{id: id, action: action, reload: resContainers}
+		if err := fn(ctx, cli, id); err != nil {
+			done.err = err
+			return done
+		}
+		done.status = fmt.Sprintf("%s %s.", name, past)
+		return done
+	})
+}
+
+// removeImage removes one image, keeping parents other images still use.
+func (b *backend) removeImage(id, name string) tea.Cmd {
+	return b.run(func(ctx context.Context, cli docker.Client) tea.Msg {
+		done := actionDoneMsg{action: "Remove image", reload: resImages}
+		start := time.Now()
+		_, err := cli.ImageRemove(ctx, id, imagetypes.RemoveOptions{PruneChildren: true})
+		logCall("ImageRemove", start, err, "id", format.Short12(format.StripSha256(id)))
+		if err != nil {
+			done.err = err
+			return done
+		}
+		done.status = fmt.Sprintf("Removed %s.", name)
+		return done
+	})
+}
@@ -0,0 +1,1969 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Antityping/superdocker/docker"
+	"github.com/Antityping/superdocker/format"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/docker/docker/api/types/checkpoint"
+	"github.com/docker/docker/api/types/container"
+	imagetypes "github.com/docker/docker/api/types/image"
+	networktypes "github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/docker/docker/api/types/system"
+	volumetypes "github.com/docker/docker/api/types/volume"
+)
+
+var (
+	baseStyle = lipgloss.NewStyle().
+			BorderStyle(lipgloss.NormalBorder()).
+			BorderForeground(lipgloss.Color("240"))
+
+	dividerStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("240"))
+
+	exitErrorStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("203"))
+
+	helpStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("241"))
+
+	summaryStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("252")).
+			Padding(0, 1)
+
+	titleStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("170")).
+			Padding(0, 1)
+)
+
+type model struct {
+	// shared Docker client and root context of all background commands
+	backend         *backend
+	containersTable table.Model
+	imagesTable     table.Model
+	volumesTable    table.Model
+	networksTable   table.Model
+	servicesTable   table.Model // shown on Swarm managers only
+	containers      []container.Summary
+	images          []imagetypes.Summary
+	volumes         []volumetypes.Volume
+	networks        []networktypes.Summary
+	services        []swarm.Service
+	err             error
+	loading         bool
+	stages          resourceSet   // lists the staged first load has received
+	staged          dataLoadedMsg // those lists, until all have arrived
+	loaded          resourceSet   // lists applied at least once
+	scroll          [5]int        // each table's scroll offset, see scroll.go
+	focusIndex      int           // 0: containers, 1: images, 2: volumes, 3: networks, 4: services
+	// terminal size
+	width  int
+	height int
+	layout layoutConfig
+	prefs  preferences
+	// column specs per table and the column picker state
+	columns      [5][]columnSpec
+	columnPicker bool
+	columnCursor int
+	// styles for focused vs blurred tables
+	stylesFocused table.Styles
+	stylesBlurred table.Styles
+	// borderless one-line-per-row layout for small terminals
+	compact bool
+	// text prompt shown above the help line (e.g. image load path)
+	prompt promptKind
+	input  textinput.Model
+	// full-screen view replacing the dashboard, if any
+	mode         viewMode
+	detail       viewport.Model
+	detailTitle  string
+	detailBody   string // unwrapped, so it can be rewrapped on resize
+	detailRaw    string // inspect JSON of the shown resource, if any
+	detailJSON   bool   // show detailRaw instead of detailBody
+	detailStatus string // outcome of an action on the detail screen
+	pruneArmed   bool   // the detail screen is a prune preview awaiting "y"
+	topID        string // container whose process list the detail screen shows
+	// daemon and host overview, once loaded
+	hostInfo *system.Info
+	// log view state while it is open
+	logs *logViewer
+	// open actions menu, if any
+	menu *actionMenu
+	// pending confirmation for a destructive action
+	confirm *confirmation
+	// one-line feedback for actions, rendered above the help line
+	status string
+	// in-flight actions keyed by full resource ID; their rows show spinner
+	pending map[string]bool
+	spinner spinner.Model
+	// live stats for running containers, keyed by full container ID
+	stats      map[string]containerStats
+	history    map[string]*statsHistory // recent samples for the sparklines
+	statsOn    bool                     // live stats collection, off by default
+	statsGen   int                      // generation of the stats sampling loop
+	sortBy     statsSort
+	sortPinned bool // keep the cursor on the busiest container
+	imageTree  bool // group images by repository
+	// group containers by Compose project
+	groupStacks bool
+	// how Created/Finished timestamps are shown
+	timeFormat timeFormat
+	// focused table and selected row key as of the last fetch of the info
+	// panel's extra details
+	selection string
+	// info panel section cursor and folded section titles, per table
+	sectionCursor [5]int
+	collapsed     [5]map[string]bool
+	// daemon endpoint when it is not on this machine, and whether
+	// destructive actions then need a second confirmation
+	remoteHost    string
+	remoteConfirm bool
+	// whether actions that change the daemon's state are disabled
+	readOnly bool
+	// whether destructive actions run without a confirmation
+	noConfirm bool
+	// why the last load couldn't reach the daemon, nil while connected
+	connErr error
+	// container the limits prompt applies to
+	limitsID string
+	// container the signal prompt applies to
+	signalID string
+	// container the rename prompt and image the tag prompt apply to
+	renameID string
+	tagID    string
+	// container the checkpoint prompt applies to
+	checkpointID string
+	// state of the run form while it is open
+	runForm *runForm
+	// whether the docker scout CLI plugin is installed, and the scans of
+	// images by full ID
+	scoutAvailable bool
+	scans          map[string]scoutScan
+	// state of the cleanup screen while it is open
+	cleanup *cleanupView
+	// in-flight wait for a container to exit, if any
+	waiting *containerWait
+	// container whose state transitions are being polled, if any
+	watch *containerWatch
+	// selection the last docker command was copied for, and which of its
+	// commands the copy key yields next
+	copyKey  string
+	copyNext int
+	// full ID of the image whose containers the containers table is limited to
+	usersOf string
+	// show untruncated IDs in the tables
+	fullIDs bool
+	// show the predefined bridge/host/none networks in the networks table
+	showBuiltinNetworks bool
+	// key of the resource to select once the first load arrives
+	restoreKey string
+	// `/` filter applied to all tables
+	filterText  string
+	filter      resourceFilter
+	fuzzyFilter bool
+	// daemon-side filter of each table as typed, and the table the
+	// daemon filter prompt applies to
+	daemonFilters     [5]string
+	daemonFilterTable int
+	// rowKeys holds, per table, the key (full ID, or name for volumes) of
+	// each row's resource, parallel to the table rows; "" for non-resource
+	// rows. index maps keys back to positions in the loaded slices.
+	rowKeys [5][]string
+	index   [5]map[string]int
+	// auto-refresh interval (0 disables) and whether it is paused
+	refreshEvery  time.Duration
+	refreshPaused bool
+	// inspect results for containers, fetched on selection and keyed by full ID
+	inspected        map[string]container.InspectResponse
+	networkInspected map[string]networktypes.Inspect
+	// writable layer sizes from the opt-in size query, keyed by full ID
+	sizes map[string]containerSize
+	// last few log lines per container; a nil entry marks a pending fetch
+	logTails map[string][]string
+	// checkpoints per container, fetched on selection where the daemon
+	// supports them; a nil entry marks a pending fetch
+	checkpoints map[string][]checkpoint.Summary
+	// when each container or image added by a reload first appeared
+	fresh map[string]time.Time
+	// restarts seen across reloads, per container, to spot crash loops
+	restarts map[string]*restartTrack
+}
+
+// viewMode selects what fills the screen.
+type viewMode int
+
+const (
+	viewDashboard viewMode = iota
+	viewAbout
+	viewDetail
+	viewLogs
+	viewCleanup
+)
+
+// refreshMsg asks the dashboard to reload, e.g. from an external signal.
+type refreshMsg struct{}
+
+// refreshTickMsg fires every auto-refresh interval.
+type refreshTickMsg struct{}
+
+func refreshTick(d time.Duration) tea.Cmd {
+	return tea.Tick(d, func(time.Time) tea.Msg { return refreshTickMsg{} })
+}
+
+type dataLoadedMsg struct {
+	kinds      resourceSet // which of the lists below were loaded
+	failed     resourceSet // which were asked for but failed; err says why
+	containers []container.Summary
+	images     []imagetypes.Summary
+	volumes    []volumetypes.Volume
+	networks   []networktypes.Summary
+	services   []swarm.Service
+	err        error
+}
+
+// Helper: display name of a container, derived from its ID when Names is empty
+func containerName(c container.Summary) string {
+	for _, n := range c.Names {
+		if n = strings.TrimPrefix(n, "/"); n != "" {
+			return n
+		}
+	}
+	if c.ID == "" {
+		return "-"
+	}
+	return "<" + format.Short12(c.ID) + ">"
+}
+
+// Helper: compact list of a container's ports for the table, e.g. "8080,443".
+// Published host ports are listed; unpublished ones as port/proto.
+func compactPorts(c container.Summary) string {
+	if len(c.Ports) == 0 {
+		return "-"
+	}
+	seen := map[string]bool{}
+	var ps []string
+	for _, p := range c.Ports {
+		entry := fmt.Sprintf("%d/%s", p.PrivatePort, p.Type)
+		if p.PublicPort != 0 {
+			entry = strconv.Itoa(int(p.PublicPort))
+		}
+		// IPv4 and IPv6 bindings of the same port are listed once
+		if !seen[entry] {
+			seen[entry] = true
+			ps = append(ps, entry)
+		}
+	}
+	return strings.Join(ps, ",")
+}
+
+// Helper: one mount as type, source, destination and access, e.g.
+// "bind /srv/conf:/etc/app (ro)". Volumes show their name rather than the
+// path under the Docker root. Mode flags beyond rw/ro (SELinux labels,
+// propagation) follow the access.
+func describeMount(mnt container.MountPoint) string {
+	src := mnt.Source
+	if mnt.Name != "" {
+		src = mnt.Name
+	}
+	flags := []string{"rw"}
+	if !mnt.RW {
+		flags[0] = "ro"
+	}
+	for _, f := range strings.Split(mnt.Mode, ",") {
+		if f != "" && f != "rw" && f != "ro" {
+			flags = append(flags, f)
+		}
+	}
+	// tmpfs mounts have no source
+	target := mnt.Destination
+	if src != "" {
+		target = format.TrimTo(src, 30) + ":" + target
+	}
+	return fmt.Sprintf("%s %s (%s)", format.OrDash(string(mnt.Type)), target, strings.Join(flags, ", "))
+}
+
+// layoutConfig controls how the terminal width is split between the tables
+// (left) and the info panel (right).
+type layoutConfig struct {
+	splitRatio    float64 // share of the width given to the tables
+	infoMaxWidth  int     // cap on the info panel width; 0 means no cap
+	minTableWidth int     // narrowest the tables get beside the info panel
+	minInfoWidth  int     // narrowest the info panel gets beside the tables
+	hideInfo      bool    // leave out the info panel, giving the tables all the width
+}
+
+func defaultLayout() layoutConfig {
+	return layoutConfig{splitRatio: 0.3, infoMaxWidth: 120, minTableWidth: 20, minInfoWidth: 30}
+}
+
+// narrowWidth is the terminal width below which the side-by-side layout
+// gives way to the single-column one.
+const narrowWidth = 80
+
+// stacked reports whether total is too narrow for the tables and the info
+// panel side by side, in which case the panel goes below the tables.
+func (cfg layoutConfig) stacked(total int) bool {
+	return total < narrowWidth || total < cfg.minTableWidth+cfg.minInfoWidth
+}
+
+// Helper: clip every line of a rendered block to width cells
+func clipWidth(s string, width int) string {
+	return lipgloss.NewStyle().MaxWidth(width).Render(s)
+}
+
+// Helper: keep at most n lines of a rendered block
+func clipLines(s string, n int) string {
+	lines := strings.Split(s, "\n")
+	if n >= 0 && len(lines) > n {
+		lines = lines[:n]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Helper: compute left/right column widths from total width. Once the info
+// panel reaches its maximum readable width, extra columns go to the tables.
+// Both sides are clamped to their minimums; when total cannot fit them, the
+// layout is stacked and each side gets the full width. A hidden info panel
+// gets no width at all.
+func computeColumnsWidth(total int, cfg layoutConfig) (int, int) {
+	if cfg.hideInfo {
+		return total, 0
+	}
+	if cfg.stacked(total) {
+		return total, total
+	}
+	lw := int(math.Round(float64(total) * cfg.splitRatio))
+	lw = max(lw, cfg.minTableWidth)
+	rw := total - lw
+	if cfg.infoMaxWidth > 0 && rw > cfg.infoMaxWidth {
+		rw = cfg.infoMaxWidth
+		lw = total - rw
+	}
+	if rw < cfg.minInfoWidth {
+		rw = cfg.minInfoWidth
+		lw = total - rw
+	}
+	return lw, rw
+}
+
+// Helper: get info panel title and body based on focus
+func (m model) infoTitleAndBody() (string, string) {
+	switch m.focusIndex {
+	case 1:
+		return titleStyle.Render("Image Info"), m.renderSelectedImageInfo()
+	case 2:
+		return titleStyle.Render("Volume Info"), m.renderSelectedVolumeInfo()
+	case 3:
+		return titleStyle.Render("Network Info"), m.renderSelectedNetworkInfo()
+	case 4:
+		return titleStyle.Render("Service Info"), m.renderSelectedServiceInfo()
+	default:
+		if project, ok := m.selectedStack(); ok {
+			return titleStyle.Render("Stack Info"), m.renderStackInfo(project)
+		}
+		return titleStyle.Render("Container Info"), m.renderSelectedContainerInfo()
+	}
+}
+
+func initialModel(layout layoutConfig, prefs preferences, refreshEvery time.Duration) model {
+	var columns [5][]columnSpec
+	for i := range columns {
+		columns[i] = columnsFromPrefs(i, prefs)
+	}
+
+	// Containers table
+	containersTable := table.New(
+		table.WithColumns(tableColumns(columns[0])),
+		table.WithFocused(true),
+		table.WithHeight(tableHeights[0]),
+	)
+
+	// Images table
+	imagesTable := table.New(
+		table.WithColumns(tableColumns(columns[1])),
+		table.WithFocused(false),
+		table.WithHeight(tableHeights[1]),
+	)
+
+	// Volumes table
+	volumesTable := table.New(
+		table.WithColumns(tableColumns(columns[2])),
+		table.WithFocused(false),
+		table.WithHeight(tableHeights[2]),
+	)
+
+	// Networks table
+	networksTable := table.New(
+		table.WithColumns(tableColumns(columns[3])),
+		table.WithFocused(false),
+		table.WithHeight(tableHeights[3]),
+	)
+
+	// Swarm services table
+	servicesTable := table.New(
+		table.WithColumns(tableColumns(columns[4])),
+		table.WithFocused(false),
+		table.WithHeight(tableHeights[4]),
+	)
+
+	// Base styles shared by focused/blurred variants
+	sBase := table.DefaultStyles()
+	sBase.Header = sBase.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		BorderBottom(true).
+		Bold(true).
+		Foreground(lipgloss.Color("170"))
+	// Blurred tables: subtle selection color
+	sBlur := sBase
+	sBlur.Selected = sBlur.Selected.
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(false)
+	// Focused table: more vivid selection color
+	sFocus := sBase
+	sFocus.Selected = sFocus.Selected.
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("63")).
+		Bold(true)
+	// Without color, tell the selection apart by attributes alone
+	if monochrome() {
+		sBlur.Selected = lipgloss.NewStyle().Underline(true)
+		sFocus.Selected = lipgloss.NewStyle().Reverse(true).Bold(true)
+	}
+
+	// Apply initial styles (containers start focused)
+	containersTable.SetStyles(sFocus)
+	imagesTable.SetStyles(sBlur)
+	volumesTable.SetStyles(sBlur)
+	networksTable.SetStyles(sBlur)
+	servicesTable.SetStyles(sBlur)
+
+	input := textinput.New()
+	input.CharLimit = 4096
+
+	spin := spinner.New(spinner.WithSpinner(spinner.MiniDot))
+
+	m := model{
+		layout:           layout,
+		refreshEvery:     refreshEvery,
+		prefs:            prefs,
+		columns:          columns,
+		pending:          map[string]bool{},
+		spinner:          spin,
+		inspected:        map[string]container.InspectResponse{},
+		networkInspected: map[string]networktypes.Inspect{},
+		logTails:         map[string][]string{},
+		checkpoints:      map[string][]checkpoint.Summary{},
+		scans:            map[string]scoutScan{},
+		fresh:            map[string]time.Time{},
+		restarts:         map[string]*restartTrack{},
+		history:          map[string]*statsHistory{},
+		input:            input,
+		containersTable:  containersTable,
+		imagesTable:      imagesTable,
+		volumesTable:     volumesTable,
+		networksTable:    networksTable,
+		servicesTable:    servicesTable,
+		loading:          true,
+		stylesFocused:    sFocus,
+		stylesBlurred:    sBlur,
+	}
+	// Validated in main, so an unknown name can only fall back to relative
+	m.timeFormat, _ = parseTimeFormat(prefs.TimeFormat)
+	m.fullIDs = prefs.FullIDs
+	m.layout.hideInfo = prefs.HideInfo
+	m.sortBy = parseSortKey(prefs.Sort[tableNames[0]])
+	m.applyColumns(0)
+	// Reopen on the table and resource focused when the last session quit
+	for i, name := range tableNames {
+		if name == prefs.Focus {
+			m.focusTable(i)
+			m.restoreKey = prefs.Selected
+		}
+	}
+	return m
+}
+
+func (m model) Init() tea.Cmd {
+	cmds := []tea.Cmd{m.backend.loadStaged(), m.backend.loadHostInfo(), m.backend.detectScout()}
+	if m.refreshEvery > 0 {
+		cmds = append(cmds, refreshTick(m.refreshEvery))
+	}
+	return tea.Batch(cmds...)
+}
+
+// Update handles msg, then fetches the info panel's extra details when the
+// selection changed, whether by navigation, focus, filtering or a reload.
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	next, cmd := m.update(msg)
+	nm, ok := next.(model)
+	if !ok {
+		return next, cmd
+	}
+	for i := range nm.scroll {
+		nm.clampScroll(i)
+	}
+	if key := nm.selectionKey(); key != nm.selection {
+		nm.selection = key
+		// A watch belongs to the container it was started on
+		if nm.watch != nil && (nm.focusIndex != 0 || nm.selectedRowKey(0) != nm.watch.id) {
+			nm = nm.stopWatch()
+		}
+		cmd = tea.Batch(cmd, nm.fetchSelectionDetails())
+	}
+	return nm, cmd
+}
+
+func (m model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.resizeTables()
+		m.resizeInput()
+		if m.runForm != nil {
+			f := *m.runForm
+			f.resize(m.width)
+			m.runForm = &f
+		}
+		if m.mode == viewDetail {
+			m.resizeDetail()
+		}
+		if m.mode == viewLogs {
+			m.logs.resize(m.width, m.height)
+		}
+		return m, nil
+	case tea.KeyMsg:
+		if m.mode == viewAbout {
+			if msg.String() == "ctrl+c" {
+				return m.quit()
+			}
+			m.mode = viewDashboard
+			return m, nil
+		}
+		if m.mode == viewDetail {
+			return m.updateDetail(msg)
+		}
+		if m.mode == viewLogs {
+			if m.prompt != promptNone {
+				return m.updatePrompt(msg)
+			}
+			return m.updateLogs(msg)
+		}
+		if m.mode == viewCleanup {
+			return m.updateCleanup(msg)
+		}
+		if m.confirm != nil {
+			return m.updateConfirm(msg)
+		}
+		if m.menu != nil {
+			return m.updateMenu(msg)
+		}
+		if m.runForm != nil {
+			return m.updateRunForm(msg)
+		}
+		if m.columnPicker {
+			return m.updateColumnPicker(msg)
+		}
+		if m.prompt != promptNone {
+			return m.updatePrompt(msg)
+		}
+		if m.readOnly && mutatingKeys[msg.String()] {
+			m.status = readOnlyStatus
+			return m, nil
+		}
+		switch msg.String() {
+		case "esc":
+			if m.waiting != nil {
+				return m.stopWaiting()
+			}
+			return m.quit()
+		case "q", "ctrl+c":
+			return m.quit()
+		case "r":
+			// Only the first load blanks the screen; later reloads keep the
+			// dashboard interactive
+			if m.containers == nil && m.images == nil {
+				m.loading = true
+				m.stages, m.staged = 0, dataLoadedMsg{}
+				return m, tea.Batch(m.backend.loadStaged(), m.backend.loadHostInfo())
+			}
+			m.status = "Refreshing..."
+			return m, tea.Batch(m.backend.loadData(), m.backend.loadHostInfo())
+		case "ctrl+r":
+			return m.startReconnect()
+		case "tab":
+			return m.nextPanel()
+		case "right":
+			return m.nextPanel()
+		case "L":
+			return m.openPrompt(promptLoadImage, "Load image from tar: ", "")
+		case "s":
+			m.setSort((m.sortBy + 1) % 3)
+			return m, nil
+		case "p":
+			if m.refreshEvery == 0 {
+				m.status = "Auto-refresh is disabled (--refresh 0)."
+				return m, nil
+			}
+			m.refreshPaused = !m.refreshPaused
+			return m, nil
+		case "z":
+			m.status = "Computing container sizes (this can take a while)..."
+			return m, m.backend.loadContainerSizes()
+		case "c":
+			m.columnPicker = true
+			m.columnCursor = 0
+			m.status = ""
+			return m, nil
+		case "enter":
+			return m.openDetail()
+		case "P":
+			return m.openPrunePreview()
+		case "x":
+			if m.focusIndex == 0 {
+				return m.openDiff()
+			}
+		case "o":
+			if m.focusIndex == 0 {
+				return m.openTop()
+			}
+		case "/":
+			return m.openPrompt(promptFilter, "Filter (text, label:key[=value], port:N): ", m.filterText)
+		case "?":
+			m.mode = viewAbout
+			return m, nil
+		case "t":
+			if m.focusIndex == 1 {
+				m.imageTree = !m.imageTree
+				m.setImageRows()
+				return m, nil
+			}
+		case "F":
+			return m.openDaemonFilterPrompt()
+		case "E":
+			if m.focusIndex == 0 {
+				return m.cycleStateFilter()
+			}
+		case "b":
+			if m.focusIndex == 0 {
+				return m.copyIP()
+			}
+		case "O":
+			if m.focusIndex == 0 {
+				return m.confirmAttach()
+			}
+		case "V":
+			if m.focusIndex == 1 && m.scoutAvailable {
+				return m.startScan()
+			}
+		case "f":
+			m.fuzzyFilter = !m.fuzzyFilter
+			m.status = "Filter matching: exact"
+			if m.fuzzyFilter {
+				m.status = "Filter matching: fuzzy"
+			}
+			m.setFilter(m.filterText)
+			return m, nil
+		case "a":
+			if m.focusIndex == 3 {
+				m.showBuiltinNetworks = !m.showBuiltinNetworks
+				m.setNetworkRows()
+				return m, nil
+			}
+		case "u":
+			if m.focusIndex == 1 {
+				return m.showImageUsers()
+			}
+			if m.focusIndex == 0 && m.usersOf != "" {
+				return m.clearImageUsers()
+			}
+		case "i":
+			if m.focusIndex == 0 {
+				return m.jumpToImage()
+			}
+		case "l":
+			if m.focusIndex == 0 {
+				return m.openLogs()
+			}
+		case "w":
+			if m.focusIndex == 0 {
+				return m.startWait()
+			}
+		case "W":
+			if m.focusIndex == 0 {
+				return m.toggleWatch()
+			}
+		case "e":
+			if m.focusIndex == 0 {
+				return m.confirmRestartPolicy()
+			}
+		case "M":
+			if m.focusIndex == 0 {
+				return m.openLimitsPrompt()
+			}
+		case "K":
+			if m.focusIndex == 0 {
+				return m.confirmSignal()
+			}
+		case "n":
+			if m.focusIndex == 0 {
+				return m.openRenamePrompt()
+			}
+			if m.focusIndex == 1 {
+				return m.openTagPrompt()
+			}
+		case "N":
+			return m.openPullPrompt()
+		case "U":
+			return m.openCleanup()
+		case "C":
+			return m.toggleStats()
+		case "y":
+			return m.copyCommand()
+		case "Y":
+			return m.copyTable(false)
+		case "ctrl+y":
+			return m.copyTable(true)
+		case "m":
+			return m.openMenu()
+		case "d":
+			if m.focusIndex == 0 {
+				return m.confirmRemoveContainer(false)
+			}
+			if m.focusIndex == 1 {
+				return m.confirmDanglingClean()
+			}
+		case "D":
+			if m.focusIndex == 0 {
+				return m.confirmRemoveContainer(true)
+			}
+		case "v":
+			m.setCompact(!m.compact)
+			return m, nil
+		case "A":
+			if project, ok := m.selectedStack(); ok && m.focusIndex == 0 {
+				return m.confirmStackAction(project)
+			}
+			return m.confirmBulk()
+		case "T":
+			m.timeFormat = (m.timeFormat + 1) % timeFormat(len(timeFormatNames))
+			m.prefs.TimeFormat = m.timeFormat.String()
+			m.status = fmt.Sprintf("Timestamps: %s", m.timeFormat)
+			if err := savePrefs(m.prefs); err != nil {
+				m.status = fmt.Sprintf("Could not save preferences: %v", err)
+			}
+			return m, nil
+		case "I":
+			m.setFullIDs(!m.fullIDs)
+			return m, nil
+		case "h":
+			m.setHideInfo(!m.layout.hideInfo)
+			return m, nil
+		case "!":
+			m.setNoConfirm(!m.noConfirm)
+			return m, nil
+		case "[":
+			m.moveSection(-1)
+			return m, nil
+		case "]":
+			m.moveSection(1)
+			return m, nil
+		case " ":
+			m.toggleSection()
+			return m, nil
+		case "g":
+			if m.focusIndex == 0 {
+				m.groupStacks = !m.groupStacks
+				m.setContainerRows()
+				return m, nil
+			}
+		case "R":
+			if m.focusIndex == 0 {
+				return m.confirmRecreate()
+			}
+			if m.focusIndex == 1 {
+				return m.openRunForm()
+			}
+		case "S":
+			m.sortPinned = !m.sortPinned
+			m.status = "Busiest container pin: off"
+			if m.sortPinned {
+				m.status = "Busiest container pin: on"
+			}
+			m.setContainerRows()
+			return m, nil
+		}
+
+	case reconnectedMsg:
+		return m.applyReconnect(msg)
+
+	case refreshMsg:
+		return m, tea.Batch(m.backend.loadData(), m.backend.loadHostInfo())
+
+	case pullDoneMsg:
+		if msg.err != nil {
+			m.status = fmt.Sprintf("Pull of %s failed: %v", msg.ref, msg.err)
+			return m, nil
+		}
+		m.status = fmt.Sprintf("Pulled %s.", msg.ref)
+		if err := m.recordPull(msg.ref); err != nil {
+			m.status = fmt.Sprintf("Pulled %s; could not save pull history: %v", msg.ref, err)
+		}
+		return m, m.backend.loadImages()
+
+	case hostInfoMsg:
+		// The overview is extra context; without it the dashboard still works
+		if msg.err != nil {
+			debugLog.Warn("host info unavailable", "err", msg.err)
+			return m, nil
+		}
+		first, wasManager := m.hostInfo == nil, m.swarmManager()
+		m.hostInfo = &msg.info
+		if first || wasManager != m.swarmManager() {
+			m.resizeTables()
+		}
+		// The services table comes and goes with the node's manager role
+		if !m.swarmManager() {
+			if m.focusIndex == 4 {
+				m.focusTable(0)
+			}
+			return m, nil
+		}
+		if m.rowKeys[4] == nil {
+			return m, m.backend.load(resServices)
+		}
+		return m, nil
+
+	case refreshTickMsg:
+		if m.refreshPaused {
+			return m, refreshTick(m.refreshEvery)
+		}
+		return m, tea.Batch(m.backend.loadData(), refreshTick(m.refreshEvery))
+
+	case statsTickMsg:
+		if !m.statsOn || msg.gen != m.statsGen {
+			return m, nil
+		}
+		// Stats reorder sorted rows, so they pause with auto-refresh
+		if m.refreshPaused {
+			return m, statsTick(m.statsGen)
+		}
+		return m, m.backend.pollStats(m.statsGen, m.visibleRunningContainerIDs(), m.stats)
+
+	case statsLoadedMsg:
+		if !m.statsOn || msg.gen != m.statsGen {
+			return m, nil
+		}
+		m.stats = msg.stats
+		m.recordHistory(msg.stats)
+		m.setContainerRows()
+		return m, statsTick(m.statsGen)
+
+	case imageLoadProgressMsg:
+		m.status = msg.line
+		return m, m.backend.readImageLoad(msg.stream)
+
+	case imageLoadDoneMsg:
+		if msg.err != nil {
+			m.status = fmt.Sprintf("Image load failed: %v", msg.err)
+			return m, nil
+		}
+		m.status = "Image load complete."
+		if len(msg.loaded) > 0 {
+			m.status = strings.Join(msg.loaded, " • ")
+		}
+		return m, m.backend.loadImages()
+
+	case stageLoadedMsg:
+		// A full reload may have beaten the staged one
+		if !m.loading {
+			return m, nil
+		}
+		m.addStage(msg)
+		if m.stages != allResources {
+			return m, nil
+		}
+		loaded := m.staged
+		m.staged = dataLoadedMsg{}
+		return m.update(loaded)
+
+	case dataLoadedMsg:
+		m.loading = false
+		// Only a load that got none of the Docker lists it asked for,
+		// before anything was shown, replaces the dashboard with the
+		// error; Swarm services are extra. Later failures, such as a
+		// targeted reload after an action, keep the tables as they were
+		switch {
+		case msg.err != nil && docker.IsConnectionError(msg.err):
+			m.connErr = msg.err
+		case msg.kinds != 0:
+			m.connErr = nil
+		}
+		if msg.err != nil && msg.kinds == 0 && msg.failed&allResources != 0 && m.loaded&allResources == 0 {
+			m.err = docker.ExplainError(msg.err)
+			return m, nil
+		}
+		m.err = nil
+		if m.status == "Refreshing..." {
+			m.status = ""
+		}
+		if msg.err != nil {
+			m.status = fmt.Sprintf("Could not list %s: %v", msg.failed, docker.ExplainError(msg.err))
+		}
+
+		freshCmd := m.markFresh(msg)
+		m.applyLoaded(msg)
+		// Return to the resource selected when the last session quit, or
+		// to a container just run, once its table is loaded
+		if m.restoreKey != "" && msg.kinds&(resourceSet(1)<<m.focusIndex) != 0 {
+			m.moveCursorTo(m.focusIndex, m.restoreKey)
+			m.restoreKey = ""
+		}
+		cmds := []tea.Cmd{m.inspectSelectedContainer(), m.inspectSelectedNetwork(), freshCmd}
+		// Service tasks are containers, so services follow their reloads
+		if msg.kinds&resContainers != 0 && m.swarmManager() {
+			cmds = append(cmds, m.backend.load(resServices))
+		}
+		return m, tea.Batch(cmds...)
+
+	case freshExpiredMsg:
+		if m.expireFresh() {
+			m.setContainerRows()
+			m.setImageRows()
+		}
+		return m, nil
+
+	case spinner.TickMsg:
+		if len(m.pending) == 0 {
+			return m, nil
+		}
+		m.spinner, cmd = m.spinner.Update(msg)
+		m.setContainerRows()
+		return m, cmd
+
+	case actionDoneMsg:
+		delete(m.pending, msg.id)
+		m.setContainerRows()
+		if msg.err != nil {
+			m.status = fmt.Sprintf("%s failed: %v", msg.action, msg.err)
+			return m, nil
+		}
+		m.forgetContainer(msg.id)
+		m.status = msg.status
+		return m, m.backend.load(msg.reload)
+
+	case waitDoneMsg:
+		// A newer wait or esc may have replaced and canceled this one
+		if m.waiting == nil || m.waiting.id != msg.id || errors.Is(msg.err, context.Canceled) {
+			return m, nil
+		}
+		name := m.waiting.name
+		m.waiting.cancel()
+		m.waiting = nil
+		if msg.err != nil {
+			m.status = fmt.Sprintf("Wait for %s failed: %v", name, msg.err)
+			return m, nil
+		}
+		m.status = fmt.Sprintf("%s exited with code %d.", name, msg.code)
+		return m, m.backend.loadContainers()
+
+	case clipboardMsg:
+		status := msg.done
+		if msg.err != nil {
+			status = fmt.Sprintf("Copy failed: %v", msg.err)
+		}
+		// The detail screen has its own status line
+		if m.mode == viewDetail {
+			m.detailStatus = status
+		} else {
+			m.status = status
+		}
+		return m, nil
+
+	case detailLoadedMsg:
+		if m.mode != viewDetail || m.pruneArmed {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.mode = viewDashboard
+			m.status = fmt.Sprintf("Inspect failed: %v", msg.err)
+			return m, nil
+		}
+		m.detailTitle = msg.title
+		m.detailRaw = msg.raw
+		m.setDetailBody(msg.body)
+		if m.topID != "" {
+			return m, topTick(m.topID)
+		}
+		return m, nil
+
+	case topTickMsg:
+		// The processes view may have been closed since the tick was set
+		if m.mode != viewDetail || m.topID != msg.id {
+			return m, nil
+		}
+		return m, m.refreshTop()
+
+	case watchTickMsg:
+		if m.watch != msg.watch {
+			return m, nil
+		}
+		return m, m.backend.pollWatch(m.watch)
+
+	case watchPolledMsg:
+		if m.watch != msg.watch {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.status = fmt.Sprintf("Watch of %s stopped: %v", m.watch.name, msg.err)
+			m.watch = nil
+			return m, m.backend.loadContainers()
+		}
+		if m.applyWatchPoll(msg) {
+			return m, tea.Batch(watchTick(m.watch), m.backend.loadContainers())
+		}
+		return m, watchTick(m.watch)
+
+	case containerSizesMsg:
+		if msg.err != nil {
+			m.status = fmt.Sprintf("Size query failed: %v", msg.err)
+			return m, nil
+		}
+		m.sizes = msg.sizes
+		m.status = sizeSummary(msg.sizes)
+		m.setContainerRows()
+		return m, nil
+
+	case scoutDetectedMsg:
+		m.scoutAvailable = msg.ok
+		return m, nil
+
+	case scoutScannedMsg:
+		return m.applyScan(msg)
+
+	case containerRunMsg:
+		return m.applyRun(msg)
+
+	case attachDoneMsg:
+		return m.applyAttachDone(msg)
+
+	case containerInspectedMsg:
+		if msg.err != nil {
+			// Drop the pending marker so the next selection retries
+			delete(m.inspected, msg.id)
+			return m, nil
+		}
+		m.inspected[msg.id] = msg.info
+		// The daemon's restart count may reveal a loop the reloads missed
+		if _, _, looping := m.crashLoop(msg.id); looping {
+			m.setContainerRows()
+		}
+		return m, tea.Batch(m.logTailFor(msg.info), m.checkpointsFor(msg.id))
+
+	case logLinesMsg:
+		if !m.addLogLines(msg) || msg.done {
+			return m, nil
+		}
+		return m, m.backend.readLogs(msg.stream)
+
+	case diskUsageMsg:
+		if m.cleanup != nil {
+			m.cleanup.applyDiskUsage(msg)
+		}
+		return m, nil
+
+	case checkpointsLoadedMsg:
+		return m.applyCheckpoints(msg)
+
+	case logTailMsg:
+		if msg.err != nil {
+			m.logTails[msg.id] = []string{"(logs unavailable: " + msg.err.Error() + ")"}
+			return m, nil
+		}
+		m.logTails[msg.id] = msg.lines
+		return m, nil
+
+	case networkInspectedMsg:
+		if msg.err != nil {
+			delete(m.networkInspected, msg.id)
+			return m, nil
+		}
+		m.networkInspected[msg.id] = msg.info
+		return m, nil
+	}
+
+	// Non-key messages (e.g. cursor blink, clipboard paste) belong to the
+	// prompt while it is open
+	if m.prompt != promptNone {
+		return m.updateInput(msg)
+	}
+	if m.runForm != nil {
+		return m.updateRunFormInput(msg)
+	}
+
+	// Route navigation keys to the focused table
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		m.navigate(m.focusIndex, msg)
+	}
+	return m, nil
+}
+
+// selectedRowKey returns the key (full ID, or name for volumes) of the
+// resource under the cursor of table i, or "" when none is selected.
+func (m model) selectedRowKey(i int) string {
+	keys := m.rowKeys[i]
+	cur := m.tableAt(i).Cursor()
+	if cur < 0 || cur >= len(keys) || cur >= len(m.tableAt(i).Rows()) {
+		return ""
+	}
+	return keys[cur]
+}
+
+// setRows replaces the rows of table i, recording each row's resource key,
+// and moves the cursor back to the row with the given key. The cursor is
+// moved with MoveUp/MoveDown rather than SetCursor so the table keeps its
+// scroll offset consistent.
+func (m *model) setRows(i int, rows []table.Row, keys []string, key string) {
+	t := m.tableAt(i)
+	t.SetRows(rows)
+	m.clampScroll(i)
+	m.rowKeys[i] = keys
+	if !m.moveCursorTo(i, key) {
+		// Selected resource is gone: stay at the same position, clamped
+		t.SetCursor(t.Cursor())
+	}
+}
+
+// moveCursorTo moves the cursor of table i to the row with the given key,
+// reporting whether such a row exists.
+func (m *model) moveCursorTo(i int, key string) bool {
+	if key == "" {
+		return false
+	}
+	t := m.tableAt(i)
+	for j, k := range m.rowKeys[i] {
+		if k != key {
+			continue
+		}
+		if cur := t.Cursor(); j > cur {
+			m.moveDown(i, j-cur)
+		} else if j < cur {
+			m.moveUp(i, cur-j)
+		}
+		return true
+	}
+	return false
+}
+
+// buildIndex maps each loaded resource's key to its slice position so the
+// selected row resolves to its resource in O(1).
+func (m *model) buildIndex() {
+	for i := range m.index {
+		m.index[i] = map[string]int{}
+	}
+	for i, c := range m.containers {
+		m.index[0][c.ID] = i
+	}
+	for i, img := range m.images {
+		m.index[1][img.ID] = i
+	}
+	for i, v := range m.volumes {
+		m.index[2][v.Name] = i
+	}
+	for i, n := range m.networks {
+		m.index[3][n.ID] = i
+	}
+	for i, s := range m.services {
+		m.index[4][s.ID] = i
+	}
+}
+
+// setContainerRows rebuilds the containers table from the loaded summaries and
+// live stats, keeping the cursor on the previously selected container.
+func (m *model) setContainerRows() {
+	selectedID := m.selectedRowKey(0)
+
+	top := m.topConsumer()
+	containers := m.sortedContainers()
+	if m.usersOf != "" {
+		users := containers[:0]
+		for _, c := range containers {
+			if c.ImageID == m.usersOf {
+				users = append(users, c)
+			}
+		}
+		containers = users
+	}
+	bound := containers[:0:0]
+	for _, c := range containers {
+		if m.filter.matchPorts(c.Ports) {
+			bound = append(bound, c)
+		}
+	}
+	visible := filterItems(m.filter, bound, func(c container.Summary) (map[string]string, []string) {
+		return c.Labels, []string{c.ID, containerName(c), c.Image, c.Status}
+	})
+	var cRows []table.Row
+	var keys []string
+	if m.groupStacks {
+		cRows, keys = m.stackRows(visible, top)
+	} else {
+		cRows = make([]table.Row, 0, len(visible))
+		keys = make([]string, 0, len(visible))
+		for _, c := range visible {
+			cRows = append(cRows, m.containerRow(c, top))
+			keys = append(keys, c.ID)
+		}
+	}
+
+	if m.sortPinned && m.sortBy != sortNone {
+		for _, k := range keys {
+			if !strings.HasPrefix(k, stackKeyPrefix) {
+				selectedID = k
+				break
+			}
+		}
+	}
+	m.setRows(0, cRows, keys, selectedID)
+}
+
+// containerRow renders one containers table row. top is the ID of the
+// busiest container, flagged in the active sort column.
+func (m model) containerRow(c container.Summary, top string) table.Row {
+	id := m.tableID(c.ID)
+	image := format.TrimTo(format.OrDash(c.Image), 25)
+	cmdStr := format.TrimTo(format.OrDash(c.Command), 20)
+	status := format.OrDash(c.Status)
+	name := containerName(c)
+	st, ok := m.stats[c.ID]
+	cpu := formatCPU(st, ok)
+	mem := formatMem(st, ok)
+	netIO := formatNetIO(st, ok)
+	// Flag the top consumer of the active sort resource
+	if c.ID == top {
+		if m.sortBy == sortMem {
+			mem = "▲" + mem
+		} else {
+			cpu = "▲" + cpu
+		}
+	}
+
+	busy := ""
+	if m.pending[c.ID] {
+		busy = m.spinner.View()
+	} else if _, _, looping := m.crashLoop(c.ID); looping {
+		busy = crashMarker
+	} else if _, ok := m.fresh[c.ID]; ok {
+		busy = freshMarker
+	}
+
+	ports := compactPorts(c)
+	size := "-"
+	if sz, ok := m.sizes[c.ID]; ok {
+		size = formatContainerSize(sz)
+	}
+
+	return table.Row{id, image, cmdStr, status, name, cpu, mem, netIO, ports, size, ageCell(unixTime(c.Created)), busy}
+}
+
+// setImageRows rebuilds the images table, keeping the selected image.
+func (m *model) setImageRows() {
+	selectedID := m.selectedRowKey(1)
+	if m.imageTree {
+		rows, keys := m.imageTreeRows()
+		m.setRows(1, rows, keys, selectedID)
+		return
+	}
+	iRows := []table.Row{}
+	keys := []string{}
+	visible := filterItems(m.filter, m.images, func(img imagetypes.Summary) (map[string]string, []string) {
+		return img.Labels, append([]string{img.ID}, img.RepoTags...)
+	})
+	used := m.usedImageIDs()
+	for _, img := range visible {
+		repoTag := "<none>:<none>"
+		if len(img.RepoTags) > 0 {
+			repoTag = img.RepoTags[0]
+		}
+		if isDangling(img) && !used[img.ID] {
+			repoTag += " " + unusedMarker
+		}
+		if _, ok := m.fresh[img.ID]; ok {
+			repoTag = freshMarker + " " + repoTag
+		}
+		imgID := m.tableID(img.ID)
+		sizeMB := fmt.Sprintf("%.1fMB", float64(img.Size)/1024.0/1024.0)
+		iRows = append(iRows, table.Row{repoTag, imgID, sizeMB, ageCell(unixTime(img.Created))})
+		keys = append(keys, img.ID)
+	}
+	m.setRows(1, iRows, keys, selectedID)
+}
+
+// setVolumeRows rebuilds the volumes table, keeping the selected volume.
+func (m *model) setVolumeRows() {
+	selectedName := m.selectedRowKey(2)
+	vRows := []table.Row{}
+	keys := []string{}
+	visible := filterItems(m.filter, m.volumes, func(v volumetypes.Volume) (map[string]string, []string) {
+		return v.Labels, []string{v.Name, v.Driver}
+	})
+	for _, v := range visible {
+		// Compose volume names carry a project prefix and can be far wider
+		// than the column; the info panel shows them in full
+		name := format.TrimTo(v.Name, m.columns[2][0].width)
+		driver := v.Driver
+		mount := format.TrimTo(v.Mountpoint, 40)
+		vRows = append(vRows, table.Row{name, driver, mount, ageCell(parseCreated(v.CreatedAt))})
+		keys = append(keys, v.Name)
+	}
+	m.setRows(2, vRows, keys, selectedName)
+}
+
+// setNetworkRows rebuilds the networks table, keeping the selected network.
+func (m *model) setNetworkRows() {
+	selectedID := m.selectedRowKey(3)
+	nRows := []table.Row{}
+	keys := []string{}
+	visible := filterItems(m.filter, m.networks, func(n networktypes.Summary) (map[string]string, []string) {
+		return n.Labels, []string{n.Name, n.ID, n.Driver}
+	})
+	for _, n := range visible {
+		if !m.showBuiltinNetworks && isPredefinedNetwork(n) {
+			continue
+		}
+		name := format.TrimTo(n.Name, m.columns[3][0].width)
+		id := m.tableID(n.ID)
+		driver := n.Driver
+		scope := n.Scope
+		nRows = append(nRows, table.Row{name, id, driver, scope, ageCell(n.Created)})
+		keys = append(keys, n.ID)
+	}
+	m.setRows(3, nRows, keys, selectedID)
+}
+
+// isPredefinedNetwork reports whether n is one of the networks the daemon
+// creates itself rather than one created by the user.
+func isPredefinedNetwork(n networktypes.Summary) bool {
+	switch n.Name {
+	case "bridge", "host", "none":
+		return true
+	}
+	return n.Ingress
+}
+
+// tableTitles returns the title of each table, in table order.
+func (m model) tableTitles() [5]string {
+	containers := "Docker Containers"
+	if m.usersOf != "" {
+		containers += " (using " + m.imageName(m.usersOf) + ")"
+	}
+	titles := [5]string{containers, "Docker Images", "Docker Volumes", m.networksTitle(), "Swarm Services"}
+	for i, f := range m.daemonFilters {
+		if f != "" {
+			titles[i] += " [" + f + "]"
+		}
+	}
+	return titles
+}
+
+// networksTitle names the networks table along with which networks it lists.
+func (m model) networksTitle() string {
+	if m.showBuiltinNetworks {
+		return "Docker Networks (all)"
+	}
+	return "Docker Networks (user-defined)"
+}
+
+func (m model) nextPanel() (tea.Model, tea.Cmd) {
+	next := (m.focusIndex + 1) % len(tableNames)
+	for !m.tableVisible(next) {
+		next = (next + 1) % len(tableNames)
+	}
+	m.focusTable(next)
+	return m, nil
+}
+
+// focusTable moves focus to table i, updating focus states and styles.
+func (m *model) focusTable(i int) {
+	m.focusIndex = i
+	for j := range tableNames {
+		t := m.tableAt(j)
+		if j == i {
+			t.Focus()
+		} else {
+			t.Blur()
+		}
+		t.SetStyles(m.tableStyles(j == i))
+	}
+}
+
+func (m model) View() string {
+	if m.err != nil {
+		view := fmt.Sprintf("\n  Error: %v\n\n  Press ctrl+r to reconnect, r to retry, q to quit.\n", m.err)
+		if m.status != "" {
+			view += "\n  " + m.status + "\n"
+		}
+		return view
+	}
+
+	if m.loading {
+		return "\n  " + m.loadingProgress() + "\n"
+	}
+
+	switch m.mode {
+	case viewAbout:
+		return m.aboutView()
+	case viewDetail:
+		return m.detailView()
+	case viewLogs:
+		return m.logsView()
+	case viewCleanup:
+		return m.cleanupView()
+	}
+
+	containersTitle := titleStyle.Render("Docker Containers")
+	imagesTitle := titleStyle.Render("Docker Images")
+	volumesTitle := titleStyle.Render("Docker Volumes")
+	networksTitle := titleStyle.Render(m.networksTitle())
+	help := m.helpView()
+
+	// Build info panel based on focus: images, volumes, networks, or containers
+
+	var content string
+	if m.width > 0 && m.height > 0 && m.layout.stacked(m.width) {
+		content = m.stackedView()
+	} else if m.compact && m.width > 0 && m.height > 0 {
+		content = m.compactView()
+	} else if m.width > 0 && m.height > 0 {
+		lw, rw := m.paneWidths()
+		// One border around all the tables, with a divider between them,
+		// and one around the info panel. Table headers can be wider than
+		// the pane, so every section is clipped to the inner width.
+		inner := lw - 2
+		titles := m.tableTitles()
+		sections := make([]string, 0, 3*len(titles))
+		for i, title := range titles {
+			if !m.tableVisible(i) {
+				continue
+			}
+			t := m.tableAt(i)
+			t.SetWidth(inner)
+			if i == m.focusIndex {
+				title = "▸ " + title
+			}
+			if len(sections) > 0 {
+				sections = append(sections, dividerStyle.Render(strings.Repeat("─", inner)))
+			}
+			sections = append(sections, clipWidth(titleStyle.Render(title), inner), clipWidth(t.View(), inner))
+		}
+		leftPane := baseStyle.Width(inner).Render(lipgloss.JoinVertical(lipgloss.Left, sections...))
+
+		content = leftPane
+		if !m.layout.hideInfo {
+			infoTitle, infoBody := m.infoTitleAndBody()
+			infoWidth := rw - 2
+			infoHeight := lipgloss.Height(leftPane) - 2
+			info := lipgloss.NewStyle().Width(infoWidth).Render(infoTitle + "\n" + infoBody)
+			rightPane := baseStyle.Width(infoWidth).Height(infoHeight).Render(clipLines(info, infoHeight))
+			content = lipgloss.JoinHorizontal(lipgloss.Top, leftPane, rightPane)
+		}
+	} else {
+		infoTitle, infoBody := m.infoTitleAndBody()
+		leftCol := fmt.Sprintf(
+			"\n%s\n\n%s\n\n%s\n\n%s\n\n%s\n\n%s\n\n%s\n\n%s",
+			containersTitle,
+			baseStyle.Render(m.containersTable.View()),
+			imagesTitle,
+			baseStyle.Render(m.imagesTable.View()),
+			volumesTitle,
+			baseStyle.Render(m.volumesTable.View()),
+			networksTitle,
+			baseStyle.Render(m.networksTable.View()),
+		)
+		rightCol := fmt.Sprintf(
+			"\n%s\n\n%s",
+			infoTitle,
+			baseStyle.Render(infoBody),
+		)
+		content = lipgloss.JoinHorizontal(lipgloss.Top, leftCol, rightCol)
+	}
+	content = fmt.Sprintf("%s\n%s", m.summaryView(), content)
+	if banner := m.remoteBanner(); banner != "" {
+		content = fmt.Sprintf("%s\n%s", banner, content)
+	}
+	if banner := m.readOnlyBanner(); banner != "" {
+		content = fmt.Sprintf("%s\n%s", banner, content)
+	}
+	if banner := m.noConfirmBanner(); banner != "" {
+		content = fmt.Sprintf("%s\n%s", banner, content)
+	}
+	if host := m.hostView(); host != "" {
+		content = fmt.Sprintf("%s\n%s", content, host)
+	}
+	if m.menu != nil {
+		content = fmt.Sprintf("%s\n%s", content, m.menuView())
+	}
+	if m.runForm != nil {
+		content = fmt.Sprintf("%s\n%s", content, m.runFormView())
+	}
+	if footer := m.footerView(); footer != "" {
+		content = fmt.Sprintf("%s\n%s", content, footer)
+	}
+	return fmt.Sprintf("%s\n%s", content, help)
+}
+
+// selectedContainer returns the container under the cursor, or nil.
+func (m model) selectedContainer() *container.Summary {
+	i, ok := m.index[0][m.selectedRowKey(0)]
+	if !ok || i >= len(m.containers) {
+		return nil
+	}
+	return &m.containers[i]
+}
+
+// summaryView renders the one-line resource overview shown above the tables,
+// truncated to the terminal width.
+func (m model) summaryView() string {
+	running := 0
+	for _, c := range m.containers {
+		if c.State == "running" {
+			running++
+		}
+	}
+	var imageBytes int64
+	for _, img := range m.images {
+		imageBytes += img.Size
+	}
+	refresh := "off"
+	switch {
+	case m.refreshEvery > 0 && m.refreshPaused:
+		refresh = "paused"
+	case m.refreshEvery > 0:
+		refresh = m.refreshEvery.String()
+	}
+	line := fmt.Sprintf("%d running / %d total containers • %d images (%s) • %d volumes • %d networks • auto-refresh: %s • daemon: %s",
+		running, len(m.containers), len(m.images), format.HumanizeBytes(imageBytes), len(m.volumes), len(m.networks), refresh, m.connectionView(),
+	)
+	if m.filterText != "" {
+		mode := "exact"
+		if m.fuzzyFilter {
+			mode = "fuzzy"
+		}
+		line += fmt.Sprintf(" • filter (%s): %s", mode, m.filterText)
+	}
+	if m.width > 0 {
+		line = format.TrimTo(line, m.width-2)
+	}
+	return summaryStyle.Render(line)
+}
+
+// renderSelectedContainerInfo renders details for the currently selected container.
+func (m model) renderSelectedContainerInfo() string {
+	sections := m.containerInfoSections()
+	if sections == nil {
+		return "No container selected."
+	}
+	return m.renderSections(0, sections)
+}
+
+// containerInfoSections builds the info panel sections of the selected
+// container, or nil when none is selected.
+func (m model) containerInfoSections() []infoSection {
+	c := m.selectedContainer()
+	if c == nil {
+		return nil
+	}
+
+	// Prepare fields
+	name := containerName(*c)
+	idShort := format.Short12(c.ID)
+	image := format.OrDash(c.Image)
+	cmd := format.OrDash(c.Command)
+	state := format.OrDash(c.State)
+	status := format.OrDash(c.Status)
+	created := m.timeFormat.renderCreated(unixTime(c.Created))
+
+	// Ports
+	ports := "-"
+	if len(c.Ports) > 0 {
+		var ps []string
+		for _, p := range c.Ports {
+			entry := fmt.Sprintf("%d/%s", p.PrivatePort, p.Type)
+			if p.PublicPort != 0 {
+				entry = fmt.Sprintf("%d->%d/%s", p.PublicPort, p.PrivatePort, p.Type)
+			}
+			if p.IP != "" {
+				entry = p.IP + ":" + entry
+			}
+			ps = append(ps, entry)
+		}
+		ports = strings.Join(ps, ", ")
+	}
+
+	// Mounts
+	mounts := "-"
+	if len(c.Mounts) > 0 {
+		var ms []string
+		for _, mnt := range c.Mounts {
+			ms = append(ms, describeMount(mnt))
+		}
+		mounts = strings.Join(ms, ", ")
+	}
+
+	// Networks
+	networks := "-"
+	if c.NetworkSettings != nil && len(c.NetworkSettings.Networks) > 0 {
+		var ns []string
+		for name := range c.NetworkSettings.Networks {
+			ns = append(ns, name)
+		}
+		networks = strings.Join(ns, ", ")
+	}
+
+	// Live stats
+	st, ok := m.stats[c.ID]
+	cpu := formatCPU(st, ok)
+	mem := formatMem(st, ok)
+	if ok && st.memLimit > 0 {
+		mem = fmt.Sprintf("%s / %s", mem, format.HumanizeBytes(int64(st.memLimit)))
+	}
+	netIO := formatNetIO(st, ok)
+	if ok && !st.hasNet {
+		netIO = "- (no network of its own)"
+	}
+	blockIO := formatBlockIO(st, ok)
+	if !m.statsOn {
+		cpu, mem, netIO, blockIO = "- (C: live stats)", "-", "-", "-"
+	}
+
+	// Entrypoint and Cmd come from inspect, fetched when the selection changes
+	entrypoint, cmdArgs := "loading...", "loading..."
+	if ci, ok := m.inspected[c.ID]; ok && ci.Config != nil {
+		entrypoint = format.QuoteArgs(ci.Config.Entrypoint)
+		cmdArgs = format.QuoteArgs(ci.Config.Cmd)
+	}
+	// Uptime counts from the last start, unlike Created
+	uptime := "-"
+	if c.State == "running" {
+		uptime = "loading..."
+		if ci, ok := m.inspected[c.ID]; ok && ci.ContainerJSONBase != nil && ci.State != nil {
+			uptime = uptimeString(ci.State.Running, ci.State.StartedAt)
+		}
+	}
+
+	size := "Size: press z to compute"
+	if sz, ok := m.sizes[c.ID]; ok {
+		size = fmt.Sprintf("Size RW: %s\nSize RootFs: %s", format.HumanizeBytes(sz.rw), format.HumanizeBytes(sz.rootFs))
+	}
+
+	resources := fmt.Sprintf("CPU: %s\nMemory: %s\nNet I/O: %s\nBlock I/O: %s\n%s", cpu, mem, netIO, blockIO, size)
+	if trend := m.renderHistory(c.ID, m.infoPanelWidth()); trend != "" {
+		resources += "\n" + trend
+	}
+
+	general := fmt.Sprintf("Name: %s\nID: %s\nImage: %s\nState: %s\nStatus: %s\nUptime: %s\nCreated: %s",
+		name, idShort, image, state, status, uptime, created)
+	if warning := m.renderCrashLoop(c.ID); warning != "" {
+		general = warning + "\n" + general
+	}
+	sections := []infoSection{
+		{"General", general},
+		{"Process", fmt.Sprintf("Command: %s\nEntrypoint: %s\nCmd: %s", cmd, entrypoint, cmdArgs)},
+	}
+	if exit := m.renderExitInfo(c.ID); exit != "" {
+		sections = append(sections, infoSection{"Exit", exit})
+	}
+	if watch := m.renderWatch(c.ID); watch != "" {
+		sections = append(sections, infoSection{"Watch", watch})
+	}
+	sections = append(sections,
+		infoSection{"Resources", resources},
+		infoSection{"Network & storage", fmt.Sprintf("Ports: %s\nMounts: %s\nNetworks: %s", ports, mounts, networks)},
+	)
+	if m.checkpointsSupported() {
+		sections = append(sections, infoSection{"Checkpoints", m.renderCheckpoints(c.ID)})
+	}
+	return append(sections, infoSection{"Logs", m.renderLogTail(c.ID, 80)})
+}
+
+// renderExitInfo describes how a stopped container ended, from its inspect
+// data: exit code (highlighted when non-zero), OOM kill and finish time.
+// It returns "" for running containers or before inspect data arrives.
+func (m model) renderExitInfo(id string) string {
+	ci, ok := m.inspected[id]
+	if !ok || ci.ContainerJSONBase == nil || ci.State == nil {
+		return ""
+	}
+	st := ci.State
+	if st.Running || st.Status == "created" {
+		return ""
+	}
+	code := fmt.Sprintf("%d", st.ExitCode)
+	if st.ExitCode != 0 {
+		code = exitErrorStyle.Render(code)
+	}
+	oom := "no"
+	if st.OOMKilled {
+		oom = exitErrorStyle.Render("yes")
+	}
+	finished := m.timeFormat.formatString(st.FinishedAt)
+	return fmt.Sprintf("Exit code: %s\nOOM killed: %s\nFinished: %s", code, oom, finished)
+}
+
+// selectedImage returns the image under the cursor, or nil. Group header
+// rows of the tree view have no key and select nothing.
+func (m model) selectedImage() *imagetypes.Summary {
+	i, ok := m.index[1][m.selectedRowKey(1)]
+	if !ok || i >= len(m.images) {
+		return nil
+	}
+	return &m.images[i]
+}
+
+func (m model) renderSelectedImageInfo() string {
+	// Group header rows in the tree view describe the whole repository
+	if repo, ok := m.selectedRepo(); ok {
+		return m.renderImageGroupInfo(repo)
+	}
+
+	sections := m.imageInfoSections()
+	if sections == nil {
+		return "No image selected."
+	}
+	return m.renderSections(1, sections)
+}
+
+// imageInfoSections builds the info panel sections of the selected image,
+// or nil when none is selected.
+func (m model) imageInfoSections() []infoSection {
+	img := m.selectedImage()
+	if img == nil {
+		return nil
+	}
+
+	// Prepare fields
+	idShort := format.Short12(format.StripSha256(img.ID))
+	tags := "<none>:<none>"
+	if len(img.RepoTags) > 0 {
+		tags = strings.Join(img.RepoTags, ", ")
+	}
+	digests := "-"
+	if len(img.RepoDigests) > 0 {
+		digests = strings.Join(img.RepoDigests, ", ")
+	}
+	sizeMB := fmt.Sprintf("%.1fMB", float64(img.Size)/1024.0/1024.0)
+	users := m.imageUsers(img.ID)
+	// The daemon reports -1 when it did not compute the count
+	count := img.Containers
+	if count < 0 {
+		count = int64(len(users))
+	}
+	containers := fmt.Sprintf("%d", count)
+	usedBy := "-"
+	if len(users) > 0 {
+		usedBy = strings.Join(users, ", ")
+	}
+
+	parent := m.imageParent(*img)
+	created := m.timeFormat.renderCreated(unixTime(img.Created))
+	platforms := "-"
+	if ps := imagePlatforms(*img); len(ps) > 0 {
+		platforms = strings.Join(ps, ", ")
+	}
+
+	sections := []infoSection{
+		{"General", fmt.Sprintf("RepoTags: %s\nID: %s\nSize: %s\nRepoDigests: %s\nParent: %s\nCreated: %s",
+			tags, idShort, sizeMB, digests, parent, created)},
+		{"Usage", fmt.Sprintf("Containers: %s\nUsed by: %s", containers, usedBy)},
+		{"Platforms", platforms},
+	}
+	if m.scoutAvailable {
+		sections = append(sections, infoSection{"Vulnerabilities", m.renderScan(img.ID)})
+	}
+	return sections
+}
+
+// imageUsers returns the names of the loaded containers created from the
+// image with the given ID, sorted.
+func (m model) imageUsers(id string) []string {
+	var names []string
+	for _, c := range m.containers {
+		if c.ImageID == id {
+			names = append(names, containerName(c))
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// imagePlatforms lists the platforms of a multi-platform image, e.g.
+// "linux/arm64/v8", marking those whose content is not pulled. Only the
+// containerd image store reports manifests; other images list none.
+func imagePlatforms(img imagetypes.Summary) []string {
+	var ps []string
+	for _, mf := range img.Manifests {
+		if mf.Kind != imagetypes.ManifestKindImage || mf.ImageData == nil {
+			continue
+		}
+		p := mf.ImageData.Platform
+		name := p.OS + "/" + p.Architecture
+		if p.Variant != "" {
+			name += "/" + p.Variant
+		}
+		if !mf.Available {
+			name += " (not pulled)"
+		}
+		ps = append(ps, name)
+	}
+	return ps
+}
+
+// selectedVolume returns the volume under the cursor, or nil.
+func (m model) selectedVolume() *volumetypes.Volume {
+	i, ok := m.index[2][m.selectedRowKey(2)]
+	if !ok || i >= len(m.volumes) {
+		return nil
+	}
+	return &m.volumes[i]
+}
+
+func (m model) renderSelectedVolumeInfo() string {
+	sections := m.volumeInfoSections()
+	if sections == nil {
+		return "No volume selected."
+	}
+	return m.renderSections(2, sections)
+}
+
+// volumeInfoSections builds the info panel sections of the selected
+// volume, or nil when none is selected.
+func (m model) volumeInfoSections() []infoSection {
+	vol := m.selectedVolume()
+	if vol == nil {
+		return nil
+	}
+
+	// Prepare fields
+	driver := vol.Driver
+	mount := format.TrimTo(vol.Mountpoint, 60)
+	labels := format.JoinKV(vol.Labels)
+	options := format.JoinKV(vol.Options)
+	created := m.timeFormat.formatString(vol.CreatedAt)
+	if t := parseCreated(vol.CreatedAt); !t.IsZero() {
+		created = m.timeFormat.renderCreated(t)
+	}
+
+	return []infoSection{
+		{"General", fmt.Sprintf("Name: %s\nDriver: %s\nMountpoint: %s\nCreated: %s", vol.Name, driver, mount, created)},
+		{"Labels & options", fmt.Sprintf("Labels: %s\nOptions: %s", labels, options)},
+	}
+}
+
+// selectedNetwork returns the network under the cursor, or nil.
+func (m model) selectedNetwork() *networktypes.Summary {
+	i, ok := m.index[3][m.selectedRowKey(3)]
+	if !ok || i >= len(m.networks) {
+		return nil
+	}
+	return &m.networks[i]
+}
+
+func (m model) renderSelectedNetworkInfo() string {
+	sections := m.networkInfoSections()
+	if sections == nil {
+		return "No network selected."
+	}
+	return m.renderSections(3, sections)
+}
+
+// networkInfoSections builds the info panel sections of the selected
+// network, or nil when none is selected.
+func (m model) networkInfoSections() []infoSection {
+	nw := m.selectedNetwork()
+	if nw == nil {
+		return nil
+	}
+
+	idShort := format.Short12(format.StripSha256(nw.ID))
+
+	info := fmt.Sprintf(
+		"Name: %s\nID: %s\nDriver: %s\nScope: %s\nInternal: %t\nAttachable: %t\nIngress: %t\nEnableIPv6: %t\nCreated: %s",
+		nw.Name,
+		idShort,
+		nw.Driver,
+		nw.Scope,
+		nw.Internal,
+		nw.Attachable,
+		nw.Ingress,
+		nw.EnableIPv6,
+		m.timeFormat.renderCreated(nw.Created),
+	)
+
+	// IPAM and endpoints come from inspect, fetched when the selection changes
+	ipam, endpoints := "Subnets: loading...", "Containers: loading..."
+	if detail, ok := m.networkInspected[nw.ID]; ok && detail.ID != "" {
+		ipam, endpoints = renderIPAM(detail.IPAM), renderEndpoints(detail.Containers)
+	}
+	return []infoSection{
+		{"General", info},
+		{"Driver options", renderDriverOptions(nw.Driver, nw.Options)},
+		{"IPAM", ipam},
+		{"Endpoints", endpoints},
+	}
+}
+
+// renderIPAM lists each IPAM config's subnet and gateway.
+func renderIPAM(ipam networktypes.IPAM) string {
+	if len(ipam.Config) == 0 {
+		return "Subnets: -"
+	}
+	lines := []string{"Subnets:"}
+	for _, cfg := range ipam.Config {
+		line := fmt.Sprintf("  %s gateway %s", format.OrDash(cfg.Subnet), format.OrDash(cfg.Gateway))
+		if cfg.IPRange != "" {
+			line += fmt.Sprintf(" range %s", cfg.IPRange)
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderEndpoints lists the containers attached to a network with their IPs.
+func renderEndpoints(eps map[string]networktypes.EndpointResource) string {
+	if len(eps) == 0 {
+		return "Containers: -"
+	}
+	lines := make([]string, 0, len(eps))
+	for _, ep := range eps {
+		ips := []string{}
+		if ep.IPv4Address != "" {
+			ips = append(ips, ep.IPv4Address)
+		}
+		if ep.IPv6Address != "" {
+			ips = append(ips, ep.IPv6Address)
+		}
+		lines = append(lines, fmt.Sprintf("  %s %s", format.OrDash(ep.Name), format.OrDash(strings.Join(ips, " "))))
+	}
+	sort.Strings(lines)
+	return "Containers:\n" + strings.Join(lines, "\n")
+}
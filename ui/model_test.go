@@ -0,0 +1,420 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Antityping/superdocker/docker/dockertest"
+	"github.com/Antityping/superdocker/format"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	imagetypes "github.com/docker/docker/api/types/image"
+	networktypes "github.com/docker/docker/api/types/network"
+	volumetypes "github.com/docker/docker/api/types/volume"
+)
+
+// newTestModel returns a dashboard backed by fake, before its first load.
+func newTestModel(t *testing.T, fake *dockertest.Fake) model {
+	t.Helper()
+	m := initialModel(defaultLayout(), preferences{}, 0)
+	m.backend = newTestBackend(fake)
+	t.Cleanup(m.backend.stop)
+	return m
+}
+
+// send passes msg through Update, dropping the commands it returns.
+func send(t *testing.T, m model, msg tea.Msg) model {
+	t.Helper()
+	next, _ := m.Update(msg)
+	nm, ok := next.(model)
+	if !ok {
+		t.Fatalf("Update returned %T", next)
+	}
+	return nm
+}
+
+// hasRow reports whether some row of t has a cell equal to cell.
+func hasRow(t table.Model, cell string) bool {
+	for _, row := range t.Rows() {
+		if slices.Contains(row, cell) {
+			return true
+		}
+	}
+	return false
+}
+
+// stages lists the fake one resource at a time, like loadStaged.
+func stages(fake *dockertest.Fake) []stageLoadedMsg {
+	var msgs []stageLoadedMsg
+	for i := range tableNames {
+		kind := resourceSet(1) << i
+		if allResources&kind != 0 {
+			msgs = append(msgs, stageLoadedMsg{listResources(context.Background(), fake, kind, noFilters())})
+		}
+	}
+	return msgs
+}
+
+func TestUpdateDataLoadedFillsTables(t *testing.T) {
+	fake := newFake()
+	m := newTestModel(t, fake)
+	m = send(t, m, listResources(context.Background(), fake, allResources, noFilters()))
+
+	if m.loading || m.err != nil {
+		t.Fatalf("loading %v, err %v; want the dashboard", m.loading, m.err)
+	}
+	for i, want := range []int{2, 2, 1, 1} {
+		if got := len(m.tableAt(i).Rows()); got != want {
+			t.Errorf("%s table has %d rows; want %d", tableNames[i], got, want)
+		}
+	}
+	if !hasRow(m.containersTable, "web") || !hasRow(m.containersTable, "db") {
+		t.Errorf("containers rows = %v; want web and db", m.containersTable.Rows())
+	}
+	if !hasRow(m.imagesTable, "nginx:latest") {
+		t.Errorf("images rows = %v; want nginx:latest", m.imagesTable.Rows())
+	}
+	if !hasRow(m.volumesTable, "data") || !hasRow(m.networksTable, "backend") {
+		t.Errorf("volumes %v, networks %v; want data and backend", m.volumesTable.Rows(), m.networksTable.Rows())
+	}
+	if sel := m.selectedContainer(); sel == nil || sel.ID != fake.Containers[0].ID {
+		t.Errorf("selected %v; want the first container", sel)
+	}
+}
+
+func TestUpdateStagedLoad(t *testing.T) {
+	fake := newFake()
+	m := newTestModel(t, fake)
+	msgs := stages(fake)
+	for _, msg := range msgs[:len(msgs)-1] {
+		m = send(t, m, msg)
+		if !m.loading {
+			t.Fatalf("left the loading screen after %v", msg.kinds)
+		}
+	}
+	if p := m.loadingProgress(); !strings.Contains(p, "Loading containers... done") || strings.Contains(p, "networks... done") {
+		t.Errorf("progress = %q", p)
+	}
+	m = send(t, m, msgs[len(msgs)-1])
+	if m.loading || m.err != nil {
+		t.Fatalf("loading %v, err %v; want the dashboard once every stage arrived", m.loading, m.err)
+	}
+	if len(m.containersTable.Rows()) != 2 || len(m.networksTable.Rows()) != 1 {
+		t.Errorf("got %d containers and %d networks; want 2 and 1", len(m.containersTable.Rows()), len(m.networksTable.Rows()))
+	}
+}
+
+func TestUpdateStagedLoadFailedStage(t *testing.T) {
+	fake := newFake()
+	fake.Errs = map[string]error{"ImageList": errors.New("timeout")}
+	m := newTestModel(t, fake)
+	for _, msg := range stages(fake) {
+		m = send(t, m, msg)
+	}
+	if m.loading || m.err != nil {
+		t.Fatalf("loading %v, err %v; want the dashboard despite one failed list", m.loading, m.err)
+	}
+	if len(m.containersTable.Rows()) != 2 {
+		t.Errorf("got %d containers; want the stages that loaded", len(m.containersTable.Rows()))
+	}
+	if !strings.Contains(m.status, "Could not list images") {
+		t.Errorf("status = %q; want the failed list named", m.status)
+	}
+}
+
+func TestUpdateStagedLoadAllFailed(t *testing.T) {
+	fake := newFake()
+	boom := errors.New("boom")
+	fake.Errs = map[string]error{"ContainerList": boom, "ImageList": boom, "VolumeList": boom, "NetworkList": boom}
+	m := newTestModel(t, fake)
+	for _, msg := range stages(fake) {
+		m = send(t, m, msg)
+	}
+	if m.err == nil {
+		t.Error("no error screen when no list loaded")
+	}
+}
+
+func TestUpdateFailedReloadKeepsTables(t *testing.T) {
+	fake := newFake()
+	m := newTestModel(t, fake)
+	m = send(t, m, listResources(context.Background(), fake, allResources, noFilters()))
+
+	// A targeted reload after an image action fails
+	fake.Errs = map[string]error{"ImageList": errors.New("timeout")}
+	m = send(t, m, listResources(context.Background(), fake, resImages, noFilters()))
+	if m.err != nil {
+		t.Fatalf("err = %v; want the dashboard kept", m.err)
+	}
+	if len(m.imagesTable.Rows()) != 2 {
+		t.Errorf("got %d images; want the previous list kept", len(m.imagesTable.Rows()))
+	}
+}
+
+func TestNoConfirmRemoveRunsImmediately(t *testing.T) {
+	fake := newFake()
+	m := newTestModel(t, fake)
+	m = send(t, m, listResources(context.Background(), fake, allResources, noFilters()))
+	m = send(t, m, tea.KeyMsg{Type: tea.KeyDown})
+	db := fake.Containers[1]
+	if sel := m.selectedContainer(); sel == nil || sel.ID != db.ID {
+		t.Fatalf("selected %v; want db", sel)
+	}
+
+	m.noConfirm = true
+	next, cmd := m.confirmRemoveContainer(false)
+	m = next.(model)
+	if m.confirm != nil {
+		t.Fatalf("asked %q; want the removal to start", m.confirm.question)
+	}
+	if cmd == nil || !m.pending[db.ID] || m.status != "Removing db..." {
+		t.Errorf("pending %v, status %q; want the removal running", m.pending, m.status)
+	}
+
+	m.noConfirm = false
+	next, _ = m.confirmRemoveContainer(false)
+	if m = next.(model); m.confirm == nil || len(m.confirm.choices) != 2 {
+		t.Errorf("confirm = %+v; want the question with both variants", m.confirm)
+	}
+}
+
+// run runs cmd and the commands it batches, returning their messages.
+func run(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	msg := cmd()
+	batch, ok := msg.(tea.BatchMsg)
+	if !ok {
+		return []tea.Msg{msg}
+	}
+	var msgs []tea.Msg
+	for _, c := range batch {
+		msgs = append(msgs, run(c)...)
+	}
+	return msgs
+}
+
+func TestContainersSharingShortID(t *testing.T) {
+	fake := newFake()
+	web, db := &fake.Containers[0], &fake.Containers[1]
+	web.ID, db.ID = "0123456789abaaaaaaaa", "0123456789abbbbbbbbb"
+	db.State = "running"
+	m := newTestModel(t, fake)
+	m = send(t, m, listResources(context.Background(), fake, allResources, noFilters()))
+	if !slices.Equal(m.rowKeys[0], []string{web.ID, db.ID}) {
+		t.Fatalf("row keys = %v; want both full IDs", m.rowKeys[0])
+	}
+
+	m = send(t, m, tea.KeyMsg{Type: tea.KeyDown})
+	sel := m.selectedContainer()
+	if sel == nil || sel.ID != db.ID {
+		t.Fatalf("selected %v; want db", sel)
+	}
+	var stop menuItem
+	for _, item := range m.containerMenu(*sel).items {
+		if item.label == "Stop" {
+			stop = item
+		}
+	}
+	next, cmd := stop.run(m)
+	if m = next.(model); !m.pending[db.ID] || m.pending[web.ID] {
+		t.Errorf("pending = %v; want only db", m.pending)
+	}
+	for _, msg := range run(cmd) {
+		m = send(t, m, msg)
+	}
+	if calls := fake.Calls(); !slices.Contains(calls, "ContainerStop "+db.ID) || slices.Contains(calls, "ContainerStop "+web.ID) {
+		t.Errorf("calls = %v; want db stopped by its full ID", calls)
+	}
+	if len(m.pending) != 0 {
+		t.Errorf("pending = %v after the stop finished", m.pending)
+	}
+}
+
+// focus presses tab until table i has the focus.
+func focus(t *testing.T, m model, i int) model {
+	t.Helper()
+	for range tableNames {
+		if m.focusIndex == i {
+			return m
+		}
+		m = send(t, m, tea.KeyMsg{Type: tea.KeyTab})
+	}
+	t.Fatalf("tab never focused the %s table", tableNames[i])
+	return m
+}
+
+func TestDuplicateNamesKeepSelection(t *testing.T) {
+	fake := newFake()
+	fake.Networks = append(fake.Networks, networktypes.Summary{ID: "n2dddddddddddddddd", Name: "backend", Driver: "overlay"})
+	m := newTestModel(t, fake)
+	m = send(t, m, listResources(context.Background(), fake, allResources, noFilters()))
+	m = focus(t, m, 3)
+	m = send(t, m, tea.KeyMsg{Type: tea.KeyDown})
+	want := m.rowKeys[3][1]
+	if n := m.selectedNetwork(); n == nil || n.ID != want {
+		t.Fatalf("selected %v; want the second backend, %s", n, want)
+	}
+
+	// The daemon lists them the other way round on the next reload
+	slices.Reverse(fake.Networks)
+	m = send(t, m, listResources(context.Background(), fake, resNetworks, noFilters()))
+	if n := m.selectedNetwork(); n == nil || n.ID != want {
+		t.Errorf("selected %v after the reload; want %s", n, want)
+	}
+	if got, cur := m.selectedRowKey(3), m.networksTable.Cursor(); got != want || cur != 0 {
+		t.Errorf("cursor on row %d, %s; want it moved to row 0, %s", cur, got, want)
+	}
+	if info := ansi.Strip(m.renderSelectedNetworkInfo()); !strings.Contains(info, "ID: "+format.Short12(want)) {
+		t.Errorf("info panel doesn't show %s:\n%s", format.Short12(want), info)
+	}
+}
+
+func TestLongNamesTruncated(t *testing.T) {
+	long := "myproject_" + strings.Repeat("0123456789", 5)
+	tests := []struct {
+		name  string
+		table int
+		add   func(*dockertest.Fake)
+		info  func(model) string
+	}{
+		{"volume", 2, func(f *dockertest.Fake) {
+			f.Volumes = append(f.Volumes, &volumetypes.Volume{Name: long, Driver: "local"})
+		}, model.renderSelectedVolumeInfo},
+		{"network", 3, func(f *dockertest.Fake) {
+			f.Networks = append(f.Networks, networktypes.Summary{ID: "n2dddddddddddddddd", Name: long, Driver: "bridge"})
+		}, model.renderSelectedNetworkInfo},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFake()
+			tt.add(fake)
+			m := newTestModel(t, fake)
+			m = send(t, m, tea.WindowSizeMsg{Width: 160, Height: 40})
+			m = send(t, m, listResources(context.Background(), fake, allResources, noFilters()))
+			m = focus(t, m, tt.table)
+			m = send(t, m, tea.KeyMsg{Type: tea.KeyDown})
+
+			width := m.tableAt(tt.table).Columns()[0].Width
+			cell := m.tableAt(tt.table).SelectedRow()[0]
+			if len(cell) > width || !strings.HasSuffix(cell, "...") {
+				t.Errorf("name cell %q; want it cut to %d columns with an ellipsis", cell, width)
+			}
+			if info := ansi.Strip(tt.info(m)); !strings.Contains(info, "Name: "+long) {
+				t.Errorf("info panel doesn't show the full name:\n%s", info)
+			}
+			lines := strings.Split(m.tableAt(tt.table).View(), "\n")
+			for _, line := range lines[1:] {
+				if w, want := ansi.StringWidth(line), ansi.StringWidth(lines[0]); w != want {
+					t.Errorf("row %q is %d columns wide; want the header's %d", ansi.Strip(line), w, want)
+				}
+			}
+		})
+	}
+}
+
+func TestImagesScrollKeptAcrossFocus(t *testing.T) {
+	fake := newFake()
+	for i := range 30 {
+		fake.Images = append(fake.Images, imagetypes.Summary{
+			ID:       fmt.Sprintf("sha256:x%02d", i),
+			RepoTags: []string{fmt.Sprintf("app%02d:latest", i)},
+		})
+	}
+	m := newTestModel(t, fake)
+	m = send(t, m, tea.WindowSizeMsg{Width: 160, Height: 40})
+	m = send(t, m, listResources(context.Background(), fake, allResources, noFilters()))
+	m = focus(t, m, 1)
+	for range 20 {
+		m = send(t, m, tea.KeyMsg{Type: tea.KeyDown})
+	}
+	cur, key := m.imagesTable.Cursor(), m.selectedRowKey(1)
+	start, _ := m.visibleRows(1)
+	if cur != 20 || start == 0 {
+		t.Fatalf("cursor %d, first row on screen %d; want the images table scrolled to row 20", cur, start)
+	}
+
+	m = focus(t, m, 0)
+	m = send(t, m, listResources(context.Background(), fake, allResources, noFilters()))
+	m = focus(t, m, 1)
+	if got := m.imagesTable.Cursor(); got != cur || m.selectedRowKey(1) != key {
+		t.Errorf("cursor %d on %s; want %d on %s", got, m.selectedRowKey(1), cur, key)
+	}
+	if got, _ := m.visibleRows(1); got != start {
+		t.Errorf("first row on screen %d; want %d", got, start)
+	}
+}
+
+func TestQuitCancelsLogStream(t *testing.T) {
+	fake := newFake()
+	m := newTestModel(t, fake)
+	m = send(t, m, listResources(context.Background(), fake, allResources, noFilters()))
+	next, cmd := m.openLogs()
+	m = next.(model)
+	// The command blocks reading the stream, as under the program
+	done := make(chan tea.Msg, 1)
+	go func() { done <- cmd() }()
+
+	var streams []context.Context
+	for deadline := time.Now().Add(time.Second); len(streams) == 0; streams = fake.LogStreams() {
+		if time.Now().After(deadline) {
+			t.Fatal("the log stream never opened")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if err := streams[0].Err(); err != nil {
+		t.Fatalf("stream canceled before quitting: %v", err)
+	}
+
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("ctrl+c on the log view didn't quit")
+	}
+	select {
+	case <-streams[0].Done():
+	case <-time.After(time.Second):
+		t.Fatal("quitting left the log stream open")
+	}
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Error("the log command outlived quitting")
+	}
+}
+
+func TestPromptPaste(t *testing.T) {
+	ref := "registry.example.com:5000/team/" + strings.Repeat("a", 200) + ":v1"
+	tests := []struct {
+		name, paste, want string
+	}{
+		{"image reference", ref, ref},
+		{"over the limit", strings.Repeat("x", 5000), strings.Repeat("x", 4096)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFake()
+			m := newTestModel(t, fake)
+			m = send(t, m, tea.WindowSizeMsg{Width: 120, Height: 40})
+			m = send(t, m, listResources(context.Background(), fake, allResources, noFilters()))
+			m = send(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+			if m.prompt != promptFilter {
+				t.Fatalf("prompt = %v; want the filter prompt", m.prompt)
+			}
+			m = send(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(tt.paste), Paste: true})
+			if got := m.input.Value(); got != tt.want {
+				t.Errorf("input holds %d characters; want %d", len(got), len(tt.want))
+			}
+			m = send(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+			if m.filterText != tt.want {
+				t.Errorf("filter is %d characters; want the %d pasted", len(m.filterText), len(tt.want))
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Antityping/superdocker/format"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// driverOptionStyle highlights the driver options that matter for the
+// network's driver.
+var driverOptionStyle = lipgloss.NewStyle().
+	Bold(true).
+	Foreground(lipgloss.Color("86"))
+
+// driverOption names a network option key worth calling out.
+type driverOption struct {
+	key, label string
+}
+
+// driverOptions are the options, by driver, that explain how a network is
+// built: what multi-host or L2 debugging needs first.
+var driverOptions = map[string][]driverOption{
+	"overlay": {
+		{"com.docker.network.driver.overlay.vxlanid_list", "VXLAN ID"},
+		{"encrypted", "Encrypted"},
+		{"com.docker.network.driver.mtu", "MTU"},
+	},
+	"macvlan": {
+		{"parent", "Parent"},
+		{"macvlan_mode", "Mode"},
+	},
+	"ipvlan": {
+		{"parent", "Parent"},
+		{"ipvlan_mode", "Mode"},
+		{"ipvlan_flag", "Flag"},
+	},
+	"bridge": {
+		{"com.docker.network.bridge.name", "Bridge"},
+		{"com.docker.network.driver.mtu", "MTU"},
+		{"com.docker.network.bridge.enable_icc", "ICC"},
+		{"com.docker.network.bridge.enable_ip_masquerade", "IP masquerade"},
+		{"com.docker.network.bridge.host_binding_ipv4", "Host binding"},
+	},
+}
+
+// splitDriverOptions separates the options relevant to driver, as
+// "Label: value" lines in the driver's order, from the rest as sorted
+// "k=v" entries. macvlan and ipvlan networks without a parent are noted,
+// since the daemon then creates a dummy link with no outside connectivity.
+func splitDriverOptions(driver string, opts map[string]string) (relevant, rest []string) {
+	known := driverOptions[driver]
+	for _, o := range known {
+		if v, ok := opts[o.key]; ok {
+			relevant = append(relevant, fmt.Sprintf("%s: %s", o.label, v))
+		}
+	}
+	if (driver == "macvlan" || driver == "ipvlan") && opts["parent"] == "" {
+		relevant = append(relevant, "Parent: none (internal dummy interface)")
+	}
+	for _, kv := range format.SortedKV(opts) {
+		k, _, _ := strings.Cut(kv, "=")
+		if !slices.ContainsFunc(known, func(o driverOption) bool { return o.key == k }) {
+			rest = append(rest, kv)
+		}
+	}
+	return relevant, rest
+}
+
+// renderDriverOptions renders the info panel's driver options section:
+// the driver's own options highlighted, then the others.
+func renderDriverOptions(driver string, opts map[string]string) string {
+	relevant, rest := splitDriverOptions(driver, opts)
+	if len(relevant) == 0 && len(rest) == 0 {
+		return "Options: -"
+	}
+	lines := make([]string, 0, len(relevant)+len(rest)+1)
+	for _, l := range relevant {
+		lines = append(lines, driverOptionStyle.Render(l))
+	}
+	if len(rest) > 0 {
+		lines = append(lines, "Options:")
+		for _, kv := range rest {
+			lines = append(lines, "  "+kv)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
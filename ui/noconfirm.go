@@ -0,0 +1,35 @@
+package ui
+
+import (
+	"github.com/charmbracelet/lipgloss"
+)
+
+var noConfirmBannerStyle = lipgloss.NewStyle().
+	Bold(true).
+	Foreground(lipgloss.Color("231")).
+	Background(lipgloss.Color("125")).
+	Padding(0, 1)
+
+// noConfirmBanner renders the indicator shown above the dashboard while
+// confirmations are skipped, or "" otherwise. Read-only mode disables the
+// actions anyway, so it isn't shown then.
+func (m model) noConfirmBanner() string {
+	if !m.noConfirm || m.readOnly {
+		return ""
+	}
+	banner := noConfirmBannerStyle.Render("NO-CONFIRM: actions run without asking (! to turn off)")
+	if m.width > 0 {
+		banner = clipWidth(banner, m.width)
+	}
+	return banner
+}
+
+// setNoConfirm switches confirmation-free mode on or off.
+func (m *model) setNoConfirm(on bool) {
+	m.noConfirm = on
+	m.resizeTables() // the banner takes a line
+	m.status = "Confirmations on."
+	if on {
+		m.status = "Confirmations off: destructive actions run immediately."
+	}
+}
@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// preferences are user choices persisted across runs.
+type preferences struct {
+	// Columns lists the visible column titles per table name. Tables
+	// missing from the map use their default columns.
+	Columns map[string][]string `json:"columns,omitempty"`
+	// Focus names the table focused when the last session quit, and
+	// Selected the key (full ID, or name for volumes) of its selected row.
+	Focus    string `json:"focus,omitempty"`
+	Selected string `json:"selected,omitempty"`
+	// TimeFormat is how timestamps are shown: relative (the default),
+	// absolute or iso.
+	TimeFormat string `json:"time_format,omitempty"`
+	// FullIDs shows untruncated IDs in the tables.
+	FullIDs bool `json:"full_ids,omitempty"`
+	// Sort names the sort of each table by table name, e.g. "cpu" for
+	// the containers. Tables missing from the map are unsorted.
+	Sort map[string]string `json:"sort,omitempty"`
+	// HideInfo leaves out the info panel, giving the tables all the width.
+	HideInfo bool `json:"hide_info,omitempty"`
+	// PullHistory lists the image references pulled most recently, newest
+	// first, for the pull prompt's suggestions.
+	PullHistory []string `json:"pull_history,omitempty"`
+}
+
+// prefsPath returns the preferences file location, e.g.
+// ~/.config/superdocker/prefs.json on Linux.
+func prefsPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "superdocker", "prefs.json"), nil
+}
+
+// loadPrefs reads the preferences file. A missing file is not an error.
+func loadPrefs() (preferences, error) {
+	var p preferences
+	path, err := prefsPath()
+	if err != nil {
+		return p, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return p, nil
+	}
+	if err != nil {
+		return p, err
+	}
+	err = json.Unmarshal(data, &p)
+	return p, err
+}
+
+// savePrefs writes the preferences file, creating its directory if needed.
+func savePrefs(p preferences) error {
+	path, err := prefsPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// saveSelection records the focused table and its selected resource so the
+// next launch can return to them.
+func (m model) saveSelection() error {
+	// A session that never loaded has nothing new to remember
+	if m.rowKeys[m.focusIndex] == nil {
+		return nil
+	}
+	m.prefs.Focus = tableNames[m.focusIndex]
+	m.prefs.Selected = m.selectedRowKey(m.focusIndex)
+	return savePrefs(m.prefs)
+}
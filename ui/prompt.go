@@ -0,0 +1,327 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Antityping/superdocker/format"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/distribution/reference"
+)
+
+// promptKind identifies what the text prompt is collecting input for.
+type promptKind int
+
+const (
+	promptNone promptKind = iota
+	promptLoadImage
+	promptFilter
+	promptLimits
+	promptLogSearch
+	promptSignal
+	promptPull
+	promptRename
+	promptTag
+	promptLogSince
+	promptDaemonFilter
+	promptCheckpoint
+)
+
+var (
+	promptStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("170")).
+			Padding(0, 1)
+
+	confirmStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("203")).
+			Padding(0, 1)
+
+	statusStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("229")).
+			Padding(0, 1)
+)
+
+// confirmChoice is one accepted answer to a confirmation question.
+type confirmChoice struct {
+	key    string // key that selects this choice, e.g. "y"
+	label  string // shown in the question's key hint
+	status string // status message shown while the action runs
+	id     string // full ID of the affected resource, marked pending while cmd runs
+	cmd    tea.Cmd
+	// open, if set, runs instead of cmd, e.g. to ask for more input
+	open func(model) (tea.Model, tea.Cmd)
+}
+
+// confirmation is a pending yes/no style question guarding a destructive
+// action. Any key not matching a choice cancels it.
+type confirmation struct {
+	question string
+	choices  []confirmChoice
+	// remoteChecked is set on the second question asked on remote hosts
+	remoteChecked bool
+}
+
+// askConfirm shows question and runs the matching choice's command on answer.
+// In no-confirm mode the first choice, the plain variant of the action,
+// runs without asking.
+func (m model) askConfirm(question string, choices ...confirmChoice) (tea.Model, tea.Cmd) {
+	if m.noConfirm && len(choices) > 0 {
+		ch := choices[0]
+		if ch.open != nil {
+			return ch.open(m)
+		}
+		return m.startAction(ch.id, ch.status, ch.cmd)
+	}
+	return m.askPick(question, choices...)
+}
+
+// askPick shows question like askConfirm but always waits for the answer,
+// even in no-confirm mode: picking a signal, a policy or a network isn't a
+// confirmation, and there is no safe default to run instead.
+func (m model) askPick(question string, choices ...confirmChoice) (tea.Model, tea.Cmd) {
+	m.confirm = &confirmation{question: question, choices: choices}
+	m.status = ""
+	return m, nil
+}
+
+// updateConfirm handles the answer to a pending confirmation.
+func (m model) updateConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	c := m.confirm
+	m.confirm = nil
+	if msg.String() == "ctrl+c" {
+		return m.quit()
+	}
+	for _, ch := range c.choices {
+		if msg.String() == ch.key {
+			if ch.open != nil {
+				return ch.open(m)
+			}
+			if c.remoteChecked {
+				return m.startAction(ch.id, ch.status, ch.cmd)
+			}
+			return m.confirmRemote(ch)
+		}
+	}
+	m.status = "Cancelled."
+	return m, nil
+}
+
+// startAction runs cmd for the resource id, marking its row pending until the
+// matching actionDoneMsg arrives. The rest of the dashboard stays interactive.
+func (m model) startAction(id, status string, cmd tea.Cmd) (tea.Model, tea.Cmd) {
+	// Every action funnels through here, so this backs up the key check
+	if m.readOnly {
+		m.status = readOnlyStatus
+		return m, nil
+	}
+	m.status = status
+	if id == "" {
+		return m, cmd
+	}
+	first := len(m.pending) == 0
+	m.pending[id] = true
+	m.setContainerRows()
+	if first {
+		return m, tea.Batch(cmd, m.spinner.Tick)
+	}
+	return m, cmd
+}
+
+// openPrompt focuses the text input, prefilled with value, and starts
+// collecting input for kind.
+func (m model) openPrompt(kind promptKind, label, value string) (tea.Model, tea.Cmd) {
+	m.prompt = kind
+	m.input.Prompt = label
+	m.input.SetValue(value)
+	m.input.CursorEnd()
+	m.resizeInput()
+	m.status = ""
+	return m, m.input.Focus()
+}
+
+// resizeInput fits the text input to the terminal width so long values
+// scroll inside the prompt instead of wrapping.
+func (m *model) resizeInput() {
+	if m.width > 0 {
+		m.input.Width = max(m.width-lipgloss.Width(m.input.Prompt)-3, 10)
+	}
+}
+
+// closePrompt hides the text input without acting on it.
+func (m model) closePrompt() model {
+	m.prompt = promptNone
+	m.input.Blur()
+	m.input.SetValue("")
+	m.input.ShowSuggestions = false
+	m.input.SetSuggestions(nil)
+	return m
+}
+
+// updatePrompt handles key presses while the text prompt is open.
+func (m model) updatePrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m.quit()
+	case "esc":
+		if m.prompt == promptLogSearch {
+			m.logs.search("")
+			m.logs.render()
+		}
+		return m.closePrompt(), nil
+	case "enter":
+		if (m.prompt == promptRename || m.prompt == promptTag) && !m.renameInputValid() {
+			// The preview line already says what's wrong
+			return m, nil
+		}
+		if _, err := parseLogWindow(m.input.Value(), time.Now()); m.prompt == promptLogSince && err != nil {
+			return m, nil
+		}
+		if _, err := parseDaemonFilter(m.daemonFilterTable, m.input.Value()); m.prompt == promptDaemonFilter && err != nil {
+			return m, nil
+		}
+		if _, err := parseCheckpointName(m.input.Value(), m.checkpoints[m.checkpointID]); m.prompt == promptCheckpoint && err != nil {
+			return m, nil
+		}
+		return m.submitPrompt()
+	}
+	return m.updateInput(msg)
+}
+
+// updateInput passes msg to the text input: typed and pasted keys, and
+// the text a ctrl+v paste reads from the clipboard. Bracketed pastes
+// arrive as a single key message carrying the whole text.
+func (m model) updateInput(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	if m.prompt == promptLogSearch && m.logs.query != m.input.Value() {
+		// Search as the query is typed
+		m.logs.search(m.input.Value())
+		m.logs.jump(0)
+	}
+	return m, cmd
+}
+
+// submitPrompt acts on the entered value for the active prompt.
+func (m model) submitPrompt() (tea.Model, tea.Cmd) {
+	kind := m.prompt
+	value := m.input.Value()
+	m = m.closePrompt()
+
+	switch kind {
+	case promptFilter:
+		m.setFilter(value)
+		return m, nil
+	case promptLogSearch:
+		m.logs.search(value)
+		m.logs.jump(0)
+		return m, nil
+	case promptLogSince:
+		w, err := parseLogWindow(value, time.Now())
+		if err != nil || m.logs == nil {
+			return m, nil
+		}
+		m.logs.window = w
+		return m, m.restartLogs()
+	case promptLimits:
+		r, err := parseLimits(value)
+		if err != nil {
+			m.status = fmt.Sprintf("Limits not changed: %v", err)
+			return m, nil
+		}
+		return m.startAction(m.limitsID, "Updating limits...", m.backend.setLimits(m.limitsID, r))
+	case promptSignal:
+		signal, err := parseSignal(value)
+		if err != nil {
+			m.status = fmt.Sprintf("No signal sent: %v", err)
+			return m, nil
+		}
+		name := format.Short12(m.signalID)
+		if i, ok := m.index[0][m.signalID]; ok && i < len(m.containers) {
+			name = containerName(m.containers[i])
+		}
+		return m.confirmRemote(confirmChoice{
+			label:  "send " + signal,
+			status: fmt.Sprintf("Sending %s to %s...", signal, name),
+			id:     m.signalID,
+			cmd:    m.backend.signalContainer(m.signalID, name, signal),
+		})
+	case promptPull:
+		ref, err := parsePullRef(value)
+		if err != nil {
+			m.status = fmt.Sprintf("Pull failed: %v", err)
+			return m, nil
+		}
+		m.status = fmt.Sprintf("Pulling %s...", ref)
+		return m, m.backend.pullImage(ref)
+	case promptRename:
+		from := m.renameTarget()
+		name, err := parseContainerName(value, from)
+		if err != nil {
+			m.status = fmt.Sprintf("Not renamed: %v", err)
+			return m, nil
+		}
+		return m.startAction(m.renameID, fmt.Sprintf("Renaming %s to %s...", from, name), m.backend.renameContainer(m.renameID, from, name))
+	case promptTag:
+		ref, err := parseTagRef(value)
+		if err != nil {
+			m.status = fmt.Sprintf("Not tagged: %v", err)
+			return m, nil
+		}
+		name, target := m.imageName(m.tagID), reference.FamiliarString(ref)
+		m.status = fmt.Sprintf("Tagging %s as %s...", name, target)
+		return m, m.backend.tagImage(m.tagID, name, target)
+	case promptDaemonFilter:
+		return m.applyDaemonFilter(m.daemonFilterTable, value)
+	case promptCheckpoint:
+		return m.confirmCheckpoint(value)
+	case promptLoadImage:
+		path, err := validateTarPath(value)
+		if err != nil {
+			m.status = fmt.Sprintf("Image load failed: %v", err)
+			return m, nil
+		}
+		m.status = fmt.Sprintf("Loading image from %s...", path)
+		return m, m.backend.startImageLoad(path)
+	}
+	return m, nil
+}
+
+// footerView renders the active prompt or confirmation, or the last status
+// message.
+func (m model) footerView() string {
+	if m.confirm != nil {
+		hints := make([]string, 0, len(m.confirm.choices)+1)
+		for _, ch := range m.confirm.choices {
+			hints = append(hints, fmt.Sprintf("%s: %s", ch.key, ch.label))
+		}
+		hints = append(hints, "any other key: cancel")
+		return confirmStyle.Render(fmt.Sprintf("%s  [%s]", m.confirm.question, strings.Join(hints, " • ")))
+	}
+	if m.prompt == promptPull {
+		if hints := m.pullSuggestionsView(); hints != "" {
+			return promptStyle.Render(m.input.View()) + "\n" + hints
+		}
+	}
+	if m.prompt == promptRename || m.prompt == promptTag {
+		return promptStyle.Render(m.input.View()) + "\n" + m.renamePreview()
+	}
+	if m.prompt == promptDaemonFilter {
+		return promptStyle.Render(m.input.View()) + "\n" + m.daemonFilterPreview()
+	}
+	if m.prompt == promptCheckpoint {
+		return promptStyle.Render(m.input.View()) + "\n" + m.checkpointPreview()
+	}
+	if m.prompt != promptNone {
+		return promptStyle.Render(m.input.View())
+	}
+	if m.columnPicker {
+		return m.columnPickerView()
+	}
+	if m.status != "" {
+		return statusStyle.Render(m.status)
+	}
+	return ""
+}
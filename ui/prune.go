@@ -0,0 +1,169 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Antityping/superdocker/docker"
+	"github.com/Antityping/superdocker/format"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/docker/docker/api/types/filters"
+)
+
+// anonymousVolumeLabel marks volumes created without a name. The daemon's
+// volume prune only removes these by default.
+const anonymousVolumeLabel = "com.docker.volume.anonymous"
+
+// pruneCandidate is one resource a prune would remove. size is -1 when not
+// known, e.g. container sizes before `z` was pressed.
+type pruneCandidate struct {
+	name string
+	size int64
+}
+
+// prunePlan lists what pruning containers, images and volumes, in that
+// order, would remove.
+type prunePlan struct {
+	containers []pruneCandidate
+	images     []pruneCandidate
+	volumes    []pruneCandidate
+}
+
+// planPrune applies the daemon's prune selection to the loaded resources:
+// every stopped container, then dangling images and anonymous volumes no
+// remaining container uses. Each step sees the containers removed by the
+// one before, as the real prune does.
+func (m model) planPrune() prunePlan {
+	var p prunePlan
+	usedImages := map[string]bool{}
+	usedVolumes := map[string]bool{}
+	for _, c := range m.containers {
+		if c.State != "running" && c.State != "paused" && c.State != "restarting" {
+			size := int64(-1)
+			if sz, ok := m.sizes[c.ID]; ok {
+				size = sz.rw
+			}
+			p.containers = append(p.containers, pruneCandidate{name: containerName(c), size: size})
+			continue
+		}
+		usedImages[c.ImageID] = true
+		for _, mnt := range c.Mounts {
+			if mnt.Type == "volume" {
+				usedVolumes[mnt.Name] = true
+			}
+		}
+	}
+	for _, img := range m.images {
+		if isDangling(img) && !usedImages[img.ID] {
+			p.images = append(p.images, pruneCandidate{name: format.Short12(format.StripSha256(img.ID)), size: img.Size})
+		}
+	}
+	for _, v := range m.volumes {
+		if _, anon := v.Labels[anonymousVolumeLabel]; anon && !usedVolumes[v.Name] {
+			size := int64(-1)
+			if v.UsageData != nil && v.UsageData.Size >= 0 {
+				size = v.UsageData.Size
+			}
+			p.volumes = append(p.volumes, pruneCandidate{name: v.Name, size: size})
+		}
+	}
+	return p
+}
+
+func (p prunePlan) empty() bool {
+	return len(p.containers) == 0 && len(p.images) == 0 && len(p.volumes) == 0
+}
+
+// render lists the candidates with their sizes and the reclaimable total.
+func (p prunePlan) render() string {
+	var d detailBuilder
+	var total int64
+	unknown := false
+	for _, s := range []struct {
+		title string
+		items []pruneCandidate
+	}{
+		{"Stopped containers", p.containers},
+		{"Dangling images", p.images},
+		{"Unused anonymous volumes", p.volumes},
+	} {
+		d.section(fmt.Sprintf("%s (%d)", s.title, len(s.items)))
+		items := make([]string, 0, len(s.items))
+		for _, c := range s.items {
+			size := "size unknown"
+			if c.size >= 0 {
+				size = format.HumanizeBytes(c.size)
+				total += c.size
+			} else {
+				unknown = true
+			}
+			items = append(items, fmt.Sprintf("%s  %s", c.name, size))
+		}
+		d.list("Removes", items)
+	}
+	d.section("Total")
+	reclaim := format.HumanizeBytes(total)
+	if unknown {
+		reclaim = "at least " + reclaim + " (some sizes unknown; z computes container sizes)"
+	}
+	d.field("Reclaimable", reclaim)
+	return d.String()
+}
+
+// openPrunePreview shows what a prune would remove, without removing
+// anything, and waits for confirmation on the detail screen. In no-confirm
+// mode it prunes straight away.
+func (m model) openPrunePreview() (tea.Model, tea.Cmd) {
+	plan := m.planPrune()
+	if plan.empty() {
+		m.status = "Nothing to prune."
+		return m, nil
+	}
+	if m.noConfirm {
+		return m.startAction("", "Pruning...", m.backend.pruneResources())
+	}
+	m.mode = viewDetail
+	m.pruneArmed = true
+	m.detailTitle = "Prune preview (dry run)"
+	m.detail = viewport.New(m.width, max(m.height-4, 5))
+	m.setDetailBody(plan.render())
+	return m, nil
+}
+
+// pruneResources prunes stopped containers, then dangling images, then
+// unused anonymous volumes.
+func (b *backend) pruneResources() tea.Cmd {
+	return b.run(pruneAll)
+}
+
+func pruneAll(ctx context.Context, cli docker.Client) tea.Msg {
+	done := actionDoneMsg{action: "Prune"}
+	start := time.Now()
+	cr, err := cli.ContainersPrune(ctx, filters.NewArgs())
+	logCall("ContainersPrune", start, err)
+	if err != nil {
+		done.err = err
+		return done
+	}
+	start = time.Now()
+	ir, err := cli.ImagesPrune(ctx, filters.NewArgs(filters.Arg("dangling", "true")))
+	logCall("ImagesPrune", start, err)
+	if err != nil {
+		done.err = fmt.Errorf("containers pruned but image prune failed: %w", err)
+		return done
+	}
+	start = time.Now()
+	vr, err := cli.VolumesPrune(ctx, filters.NewArgs())
+	logCall("VolumesPrune", start, err)
+	if err != nil {
+		done.err = fmt.Errorf("containers and images pruned but volume prune failed: %w", err)
+		return done
+	}
+
+	reclaimed := int64(cr.SpaceReclaimed + ir.SpaceReclaimed + vr.SpaceReclaimed)
+	done.status = fmt.Sprintf("Pruned %d containers, %d images, %d volumes; reclaimed %s.",
+		len(cr.ContainersDeleted), len(ir.ImagesDeleted), len(vr.VolumesDeleted), format.HumanizeBytes(reclaimed))
+	return done
+}
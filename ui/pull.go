@@ -0,0 +1,128 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Antityping/superdocker/docker"
+	tea "github.com/charmbracelet/bubbletea"
+	imagetypes "github.com/docker/docker/api/types/image"
+)
+
+// pullHistoryLen caps the pulled references kept in the preferences.
+const pullHistoryLen = 20
+
+// pullSuggestionsShown is how many suggestions are listed under the prompt.
+const pullSuggestionsShown = 5
+
+// officialImages are suggested alongside the pull history and local tags.
+var officialImages = []string{
+	"alpine:latest", "busybox:latest", "debian:stable-slim", "ubuntu:latest",
+	"nginx:latest", "httpd:latest", "redis:latest", "postgres:latest",
+	"mysql:latest", "mongo:latest", "node:lts", "python:3", "golang:latest",
+}
+
+type pullDoneMsg struct {
+	ref string
+	err error
+}
+
+// openPullPrompt prompts for an image reference to pull, suggesting
+// recently pulled references first, then local tags and official images.
+func (m model) openPullPrompt() (tea.Model, tea.Cmd) {
+	next, cmd := m.openPrompt(promptPull, "Pull image: ", "")
+	nm := next.(model)
+	nm.input.ShowSuggestions = true
+	nm.input.SetSuggestions(m.pullSuggestions())
+	return nm, cmd
+}
+
+// pullSuggestions lists the completion candidates for the pull prompt,
+// without duplicates, most useful first.
+func (m model) pullSuggestions() []string {
+	var out []string
+	seen := map[string]bool{}
+	add := func(ref string) {
+		if ref != "" && ref != "<none>:<none>" && !seen[ref] {
+			seen[ref] = true
+			out = append(out, ref)
+		}
+	}
+	for _, ref := range m.prefs.PullHistory {
+		add(ref)
+	}
+	var local []string
+	for _, img := range m.images {
+		local = append(local, img.RepoTags...)
+	}
+	slices.Sort(local)
+	for _, ref := range local {
+		add(ref)
+	}
+	for _, ref := range officialImages {
+		add(ref)
+	}
+	return out
+}
+
+// pullSuggestionsView lists the top suggestions under the pull prompt,
+// the one Tab accepts first, or the recent pulls while nothing is typed.
+func (m model) pullSuggestionsView() string {
+	matched := m.input.MatchedSuggestions()
+	label, keys := "Tab: ", "   ↑/↓: cycle"
+	if m.input.Value() == "" {
+		matched, label, keys = m.prefs.PullHistory, "Recent: ", ""
+	}
+	if len(matched) == 0 {
+		return ""
+	}
+	// Start from the suggestion ↑/↓ cycled to, which is the one Tab takes
+	if i := m.input.CurrentSuggestionIndex(); m.input.Value() != "" && i < len(matched) {
+		matched = append(slices.Clone(matched[i:]), matched[:i]...)
+	}
+	if len(matched) > pullSuggestionsShown {
+		matched = matched[:pullSuggestionsShown]
+	}
+	return helpStyle.Render("  " + label + strings.Join(matched, " • ") + keys)
+}
+
+// recordPull moves ref to the front of the pull history and saves it.
+func (m *model) recordPull(ref string) error {
+	h := slices.DeleteFunc(slices.Clone(m.prefs.PullHistory), func(s string) bool { return s == ref })
+	h = append([]string{ref}, h...)
+	if len(h) > pullHistoryLen {
+		h = h[:pullHistoryLen]
+	}
+	m.prefs.PullHistory = h
+	return savePrefs(m.prefs)
+}
+
+// parsePullRef checks a typed image reference.
+func parsePullRef(s string) (string, error) {
+	ref := strings.TrimSpace(s)
+	if ref == "" {
+		return "", errors.New("enter an image reference, e.g. nginx:latest")
+	}
+	if strings.ContainsAny(ref, " \t") {
+		return "", fmt.Errorf("%q contains spaces", ref)
+	}
+	return ref, nil
+}
+
+// pullImage pulls ref, like `docker pull`.
+func (b *backend) pullImage(ref string) tea.Cmd {
+	return b.run(func(ctx context.Context, cli docker.Client) tea.Msg {
+		start := time.Now()
+		rc, err := cli.ImagePull(ctx, ref, imagetypes.PullOptions{})
+		logCall("ImagePull", start, err, "image", ref)
+		if err != nil {
+			return pullDoneMsg{ref: ref, err: err}
+		}
+		defer rc.Close()
+		return pullDoneMsg{ref: ref, err: drainJSONStream(rc)}
+	})
+}
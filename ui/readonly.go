@@ -0,0 +1,123 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var readOnlyBannerStyle = lipgloss.NewStyle().
+	Bold(true).
+	Foreground(lipgloss.Color("16")).
+	Background(lipgloss.Color("214")).
+	Padding(0, 1)
+
+// disabledHelpStyle greys out help entries for actions read-only mode
+// turns off.
+var disabledHelpStyle = lipgloss.NewStyle().
+	Foreground(lipgloss.Color("237"))
+
+// readOnlyStatus is shown when a disabled action is attempted.
+const readOnlyStatus = "read-only mode"
+
+// mutatingKeys are the dashboard keys that change the daemon's state, or
+// open the menus and prompts that do.
+var mutatingKeys = map[string]bool{
+	"R": true, // recreate a container or run an image
+	"e": true, // restart policy
+	"M": true, // CPU/memory limits
+	"K": true, // send a signal
+	"O": true, // attach to a container's main process
+	"A": true, // stop/start all
+	"P": true, // prune
+	"U": true, // clean up unused resources
+	"d": true, // remove a container or dangling images
+	"D": true, // force-remove a container
+	"n": true, // rename a container or tag an image
+	"L": true, // load image
+	"N": true, // pull image
+}
+
+// helpEntry is one "key: description" item of the dashboard help line.
+type helpEntry struct {
+	keys, desc string
+}
+
+var dashboardHelp = []helpEntry{
+	{"↑/↓", "navigate"},
+	{"Tab", "switch list"},
+	{"[/]/space", "info sections"},
+	{"enter", "details"},
+	{"m", "actions menu"},
+	{"/", "filter"},
+	{"f", "fuzzy/exact"},
+	{"F", "daemon filter"},
+	{"E", "cycle container state filter"},
+	{"r", "refresh"},
+	{"ctrl+r", "reconnect"},
+	{"p", "pause auto-refresh"},
+	{"C", "live stats"},
+	{"s/S", "sort/pin by CPU or mem"},
+	{"R", "recreate/run image"},
+	{"x", "diff"},
+	{"o", "processes"},
+	{"u/i", "image users/container's image"},
+	{"l", "logs"},
+	{"w", "wait for exit"},
+	{"W", "watch state"},
+	{"e", "restart policy"},
+	{"M", "CPU/mem limits"},
+	{"K", "send signal"},
+	{"O", "attach"},
+	{"A", "stop/start all (or stack)"},
+	{"P", "prune"},
+	{"U", "clean up unused"},
+	{"d", "remove container/dangling images"},
+	{"D", "force-remove"},
+	{"!", "toggle confirmations"},
+	{"n", "rename/tag"},
+	{"t", "image tree"},
+	{"g", "group stacks"},
+	{"a", "all networks"},
+	{"v", "compact"},
+	{"h", "hide info panel"},
+	{"T", "time format"},
+	{"I", "full IDs"},
+	{"y", "copy docker command"},
+	{"Y/ctrl+y", "copy table as text/markdown"},
+	{"b", "copy container IP"},
+	{"c", "columns"},
+	{"z", "sizes"},
+	{"N", "pull image"},
+	{"L", "load image"},
+	{"?", "about"},
+	{"q", "quit"},
+}
+
+// helpView renders the dashboard help line, greying out the actions that
+// read-only mode disables.
+func (m model) helpView() string {
+	sep := helpStyle.Render(" • ")
+	parts := make([]string, len(dashboardHelp))
+	for i, e := range dashboardHelp {
+		style := helpStyle
+		if m.readOnly && mutatingKeys[e.keys] {
+			style = disabledHelpStyle
+		}
+		parts[i] = style.Render(e.keys + ": " + e.desc)
+	}
+	return "\n  " + strings.Join(parts, sep) + "\n"
+}
+
+// readOnlyBanner renders the indicator shown above the dashboard in
+// read-only mode, or "" otherwise.
+func (m model) readOnlyBanner() string {
+	if !m.readOnly {
+		return ""
+	}
+	banner := readOnlyBannerStyle.Render("READ-ONLY")
+	if m.width > 0 {
+		banner = clipWidth(banner, m.width)
+	}
+	return banner
+}
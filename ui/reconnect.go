@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Antityping/superdocker/docker"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// reconnectedMsg reports the outcome of a reconnect: the API version the
+// daemon speaks, or why it could not be reached.
+type reconnectedMsg struct {
+	apiVersion string
+	err        error
+}
+
+// reconnect replaces the Docker client with a fresh one, which negotiates
+// the API version anew, e.g. after the daemon was restarted or upgraded.
+// The old client is kept when the daemon can't be reached yet, so a
+// failed attempt changes nothing.
+func (b *backend) reconnect() tea.Cmd {
+	return b.run(func(ctx context.Context, _ docker.Client) tea.Msg {
+		cli, err := docker.NewClient(b.host)
+		if err != nil {
+			return reconnectedMsg{err: err}
+		}
+		start := time.Now()
+		ping, err := cli.Ping(ctx)
+		logCall("Ping", start, err, "api", ping.APIVersion)
+		if err != nil {
+			cli.Close()
+			return reconnectedMsg{err: err}
+		}
+		b.mu.Lock()
+		old := b.cli
+		b.cli = cli
+		b.mu.Unlock()
+		// Calls still running on the old client fail and are retried by
+		// the reload that follows
+		old.Close()
+		return reconnectedMsg{apiVersion: ping.APIVersion}
+	})
+}
+
+// startReconnect reconnects to the daemon.
+func (m model) startReconnect() (tea.Model, tea.Cmd) {
+	m.status = "Reconnecting..."
+	return m, m.backend.reconnect()
+}
+
+// applyReconnect reloads everything once reconnected, leaving the error
+// screen of a dashboard that lost the daemon.
+func (m model) applyReconnect(msg reconnectedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.connErr = msg.err
+		m.status = fmt.Sprintf("Reconnect failed: %v", docker.ExplainError(msg.err))
+		return m, nil
+	}
+	m.err, m.connErr = nil, nil
+	m.status = fmt.Sprintf("Reconnected (API %s).", msg.apiVersion)
+	if m.containers == nil && m.images == nil {
+		m.loading = true
+		m.stages, m.staged = 0, dataLoadedMsg{}
+		return m, tea.Batch(m.backend.loadStaged(), m.backend.loadHostInfo())
+	}
+	return m, tea.Batch(m.backend.loadData(), m.backend.loadHostInfo())
+}
+
+// connectionView renders the connection state for the summary line.
+func (m model) connectionView() string {
+	if m.connErr != nil {
+		return "disconnected (ctrl+r: reconnect)"
+	}
+	return "connected"
+}
@@ -0,0 +1,46 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var remoteBannerStyle = lipgloss.NewStyle().
+	Bold(true).
+	Foreground(lipgloss.Color("231")).
+	Background(lipgloss.Color("160")).
+	Padding(0, 1)
+
+// remoteBanner renders the warning shown above the dashboard while
+// connected to a remote daemon, or "" when local.
+func (m model) remoteBanner() string {
+	if m.remoteHost == "" {
+		return ""
+	}
+	banner := remoteBannerStyle.Render("⚠ REMOTE: " + m.remoteHost)
+	if m.width > 0 {
+		banner = clipWidth(banner, m.width)
+	}
+	return banner
+}
+
+// confirmRemote runs a confirmed choice, first asking again with the host
+// named when connected to a remote daemon and --remote-confirm is on,
+// unless confirmations are off.
+func (m model) confirmRemote(ch confirmChoice) (tea.Model, tea.Cmd) {
+	if m.remoteHost == "" || !m.remoteConfirm || m.noConfirm {
+		return m.startAction(ch.id, ch.status, ch.cmd)
+	}
+	again := ch
+	again.key = "Y"
+	again.label = "yes, " + ch.label
+	m.confirm = &confirmation{
+		question:      fmt.Sprintf("This acts on REMOTE host %s. Really %s?", m.remoteHost, ch.label),
+		choices:       []confirmChoice{again},
+		remoteChecked: true,
+	}
+	m.status = ""
+	return m, nil
+}
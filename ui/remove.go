@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Antityping/superdocker/docker"
+	"github.com/Antityping/superdocker/format"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/docker/docker/api/types/container"
+)
+
+// confirmRemoveContainer asks before removing the selected container. Like
+// `docker rm`, the plain removal refuses running containers; force kills
+// them first, like `docker rm -f`. Either can also drop the container's
+// anonymous volumes.
+func (m model) confirmRemoveContainer(force bool) (tea.Model, tea.Cmd) {
+	c := m.selectedContainer()
+	if c == nil {
+		m.status = "No container selected."
+		return m, nil
+	}
+	name := containerName(*c)
+	running := c.State == "running"
+	if running && !force {
+		m.status = fmt.Sprintf("%s is running; stop it first, or press D to force-remove it.", name)
+		return m, nil
+	}
+	question := fmt.Sprintf("Remove container %s?", name)
+	if running {
+		question = fmt.Sprintf("Force-remove RUNNING container %s? It will be killed.", name)
+	}
+	choice := func(key, label string, volumes bool) confirmChoice {
+		return confirmChoice{
+			key: key, label: label,
+			status: fmt.Sprintf("Removing %s...", name),
+			id:     c.ID,
+			cmd:    m.backend.containerAction(c.ID, name, "Remove", "removed", removeContainer(force, volumes)),
+		}
+	}
+	return m.askConfirm(question,
+		choice("y", "remove", false),
+		choice("v", "remove with anonymous volumes", true),
+	)
+}
+
+// removeContainer returns the container action removing a container with
+// the given options.
+func removeContainer(force, volumes bool) func(context.Context, docker.Client, string) error {
+	return func(ctx context.Context, cli docker.Client, id string) error {
+		start := time.Now()
+		err := cli.ContainerRemove(ctx, id, container.RemoveOptions{Force: force, RemoveVolumes: volumes})
+		logCall("ContainerRemove", start, err, "id", format.Short12(id), "force", force, "volumes", volumes)
+		return err
+	}
+}
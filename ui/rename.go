@@ -0,0 +1,189 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Antityping/superdocker/docker"
+	"github.com/Antityping/superdocker/format"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/distribution/reference"
+)
+
+// containerNamePattern is the daemon's rule for container names.
+var containerNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]+$`)
+
+// openRenamePrompt prompts for a new name for the selected container,
+// prefilled with the current one.
+func (m model) openRenamePrompt() (tea.Model, tea.Cmd) {
+	c := m.selectedContainer()
+	if c == nil {
+		m.status = "No container selected."
+		return m, nil
+	}
+	m.renameID = c.ID
+	name := containerName(*c)
+	return m.openPrompt(promptRename, fmt.Sprintf("Rename %s to: ", name), name)
+}
+
+// openTagPrompt prompts for a new reference for the selected image.
+func (m model) openTagPrompt() (tea.Model, tea.Cmd) {
+	img := m.selectedImage()
+	if img == nil {
+		m.status = "No image selected."
+		return m, nil
+	}
+	m.tagID = img.ID
+	name := m.imageName(img.ID)
+	value := name
+	if name == format.Short12(format.StripSha256(img.ID)) {
+		value = "" // untagged
+	}
+	return m.openPrompt(promptTag, fmt.Sprintf("Tag %s as: ", name), value)
+}
+
+// renameTarget returns the container the rename prompt applies to, with
+// its current name.
+func (m model) renameTarget() string {
+	if i, ok := m.index[0][m.renameID]; ok && i < len(m.containers) {
+		return containerName(m.containers[i])
+	}
+	return format.Short12(m.renameID)
+}
+
+// parseContainerName checks a typed container name against the daemon's
+// naming rule, naming the part that breaks it.
+func parseContainerName(s, current string) (string, error) {
+	name := strings.TrimPrefix(strings.TrimSpace(s), "/")
+	switch {
+	case name == "":
+		return "", errors.New("enter a name")
+	case name == current:
+		return "", errors.New("same as the current name")
+	case len(name) < 2:
+		return "", errors.New("names need at least 2 characters")
+	case !isAlnum(name[0]):
+		return "", errors.New("names must start with a letter or digit")
+	case !containerNamePattern.MatchString(name):
+		return "", errors.New("names may only contain letters, digits, '_', '.' and '-'")
+	}
+	return name, nil
+}
+
+// isAlnum reports whether b is an ASCII letter or digit.
+func isAlnum(b byte) bool {
+	return 'a' <= b && b <= 'z' || 'A' <= b && b <= 'Z' || '0' <= b && b <= '9'
+}
+
+// parseTagRef checks a typed image reference for tagging, adding the
+// implied :latest tag.
+func parseTagRef(s string) (reference.NamedTagged, error) {
+	ref := strings.TrimSpace(s)
+	if ref == "" {
+		return nil, errors.New("enter a reference such as registry.local/nginx:latest")
+	}
+	named, err := reference.ParseNormalizedNamed(ref)
+	if err != nil {
+		return nil, explainRefError(ref, err)
+	}
+	if _, ok := named.(reference.Digested); ok {
+		return nil, errors.New("a tag can't include a digest")
+	}
+	tagged, ok := reference.TagNameOnly(named).(reference.NamedTagged)
+	if !ok {
+		return nil, errors.New("not a taggable reference")
+	}
+	return tagged, nil
+}
+
+// tagPattern is the registry's rule for tags.
+var tagPattern = regexp.MustCompile(`^[\w][\w.-]{0,127}$`)
+
+// explainRefError turns the reference parser's errors into the rule that
+// ref breaks; the parser mostly reports a bare "invalid reference format".
+func explainRefError(ref string, err error) error {
+	name := ref
+	if i := strings.LastIndex(ref, ":"); i > strings.LastIndex(ref, "/") {
+		name = ref[:i]
+		if tag := ref[i+1:]; !tagPattern.MatchString(tag) {
+			return fmt.Errorf("tag %q: up to 128 letters, digits, '_', '.' and '-', not starting with '.' or '-'", tag)
+		}
+	}
+	switch {
+	case strings.ToLower(name) != name:
+		return errors.New("repository names must be lowercase")
+	case strings.ContainsAny(ref, " \t"):
+		return errors.New("references can't contain spaces")
+	case errors.Is(err, reference.ErrNameTooLong):
+		return fmt.Errorf("repository names are at most %d characters", reference.NameTotalLengthMax)
+	case errors.Is(err, reference.ErrReferenceInvalidFormat):
+		return errors.New("repository names are lowercase path components separated by '/', e.g. registry.local/team/app")
+	}
+	return err
+}
+
+// renamePreview renders the outcome of the open rename or tag prompt as
+// typed, "old → new", or the rule the input breaks.
+func (m model) renamePreview() string {
+	value := m.input.Value()
+	if m.prompt == promptRename {
+		current := m.renameTarget()
+		name, err := parseContainerName(value, current)
+		if err != nil {
+			return exitErrorStyle.Render("  ✗ " + err.Error())
+		}
+		return helpStyle.Render(fmt.Sprintf("  %s → %s   enter: rename • esc: cancel", current, name))
+	}
+	ref, err := parseTagRef(value)
+	if err != nil {
+		return exitErrorStyle.Render("  ✗ " + err.Error())
+	}
+	return helpStyle.Render(fmt.Sprintf("  %s → %s   enter: tag • esc: cancel", m.imageName(m.tagID), reference.FamiliarString(ref)))
+}
+
+// renameInputValid reports whether the open rename or tag prompt holds
+// input that can be submitted.
+func (m model) renameInputValid() bool {
+	if m.prompt == promptRename {
+		_, err := parseContainerName(m.input.Value(), m.renameTarget())
+		return err == nil
+	}
+	_, err := parseTagRef(m.input.Value())
+	return err == nil
+}
+
+// renameContainer renames a container, like `docker rename`.
+func (b *backend) renameContainer(id, from, to string) tea.Cmd {
+	return b.run(func(ctx context.Context, cli docker.Client) tea.Msg {
+		done := actionDoneMsg{id: id, action: "Rename", reload: resContainers}
+		start := time.Now()
+		err := cli.ContainerRename(ctx, id, to)
+		logCall("ContainerRename", start, err, "id", format.Short12(id), "name", to)
+		if err != nil {
+			done.err = err
+			return done
+		}
+		done.status = fmt.Sprintf("Renamed %s to %s.", from, to)
+		return done
+	})
+}
+
+// tagImage adds a reference to an image, like `docker tag`.
+func (b *backend) tagImage(id, name, ref string) tea.Cmd {
+	return b.run(func(ctx context.Context, cli docker.Client) tea.Msg {
+		done := actionDoneMsg{action: "Tag", reload: resImages}
+		start := time.Now()
+		err := cli.ImageTag(ctx, id, ref)
+		logCall("ImageTag", start, err, "id", format.Short12(format.StripSha256(id)), "ref", ref)
+		if err != nil {
+			done.err = err
+			return done
+		}
+		done.status = fmt.Sprintf("Tagged %s as %s.", name, ref)
+		return done
+	})
+}
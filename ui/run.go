@@ -0,0 +1,101 @@
+// Package ui implements the superdocker dashboard: the Bubble Tea model,
+// its views and the background commands that talk to the daemon.
+package ui
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/Antityping/superdocker/docker"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Options configures a dashboard session.
+type Options struct {
+	SplitRatio    float64       // share of the terminal width given to the tables
+	InfoMaxWidth  int           // cap on the info panel width; 0 means no cap
+	MinTableWidth int           // narrowest the tables get beside the info panel
+	MinInfoWidth  int           // narrowest the info panel gets beside the tables
+	Refresh       time.Duration // auto-refresh interval; 0 disables it
+	Host          string        // normalized daemon endpoint; "" uses $DOCKER_HOST
+	RemoteConfirm bool          // ask twice before destructive actions on remote daemons
+	ReadOnly      bool          // disable every action that changes the daemon's state
+	NoConfirm     bool          // run destructive actions without asking first
+	DebugPath     string        // file receiving JSON debug logs, if any
+}
+
+// DefaultOptions returns the options used when no flags are given.
+func DefaultOptions() Options {
+	l := defaultLayout()
+	return Options{
+		SplitRatio:    l.splitRatio,
+		InfoMaxWidth:  l.infoMaxWidth,
+		MinTableWidth: l.minTableWidth,
+		MinInfoWidth:  l.minInfoWidth,
+		Refresh:       5 * time.Second,
+		RemoteConfirm: true,
+	}
+}
+
+// Run shows the dashboard until the user quits, then saves the session's
+// preferences.
+func Run(opts Options) error {
+	if opts.DebugPath != "" {
+		closeLog, err := enableDebugLog(opts.DebugPath)
+		if err != nil {
+			return fmt.Errorf("--debug: %w", err)
+		}
+		defer closeLog()
+	}
+
+	if monochrome() {
+		useMonochromeStyles()
+	}
+
+	prefs, err := loadPrefs()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: ignoring preferences: %v\n", err)
+	}
+	if _, err := parseTimeFormat(prefs.TimeFormat); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: ignoring preferences time_format: %v\n", err)
+		prefs.TimeFormat = ""
+	}
+
+	b, err := newBackend(opts.Host)
+	if err != nil {
+		if opts.Host != "" {
+			return fmt.Errorf("cannot create Docker client for %s: %w", opts.Host, err)
+		}
+		return fmt.Errorf("cannot create Docker client: %w", err)
+	}
+
+	layout := layoutConfig{
+		splitRatio:    opts.SplitRatio,
+		infoMaxWidth:  opts.InfoMaxWidth,
+		minTableWidth: opts.MinTableWidth,
+		minInfoWidth:  opts.MinInfoWidth,
+	}
+	m := initialModel(layout, prefs, opts.Refresh)
+	m.backend = b
+	m.remoteHost = docker.RemoteEndpoint(docker.Endpoint(opts.Host))
+	m.remoteConfirm = opts.RemoteConfirm
+	m.readOnly = opts.ReadOnly
+	m.noConfirm = opts.NoConfirm
+	p := tea.NewProgram(m)
+	watchRefreshSignal(b.ctx, p)
+	final, err := p.Run()
+	// tear down before reporting so no command outlives the program
+	if err := b.close(); err != nil {
+		debugLog.Error("closing docker client failed", "err", err)
+	}
+	if err != nil {
+		return err
+	}
+	if fm, ok := final.(model); ok {
+		if err := fm.saveSelection(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not save preferences: %v\n", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,322 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Antityping/superdocker/docker"
+	"github.com/Antityping/superdocker/format"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-connections/nat"
+)
+
+// Fields of the run form, in the order tab visits them.
+const (
+	runFieldName = iota
+	runFieldPorts
+	runFieldEnv
+	runFieldCommand
+	runFieldCount
+)
+
+var runFieldLabels = [runFieldCount]string{"Name", "Ports", "Env", "Command"}
+
+var runFieldPlaceholders = [runFieldCount]string{
+	"optional, e.g. web",
+	"e.g. 8080:80 127.0.0.1:5432:5432/tcp",
+	"e.g. DEBUG=1 MSG=\"hello world\"",
+	"empty: the image's default",
+}
+
+// runForm is the open form for starting a container from an image, like a
+// minimal `docker run -d`.
+type runForm struct {
+	image  string // reference passed to the daemon, a tag when there is one
+	fields [runFieldCount]textinput.Model
+	focus  int
+}
+
+// runSpec is the container a filled-in run form asks for.
+type runSpec struct {
+	name   string
+	ports  []string // as typed, for the command line
+	config container.Config
+	host   container.HostConfig
+}
+
+type containerRunMsg struct {
+	id, name string
+	err      error
+}
+
+// openRunForm opens the run form for the selected image.
+func (m model) openRunForm() (tea.Model, tea.Cmd) {
+	img := m.selectedImage()
+	if img == nil {
+		m.status = "No image selected."
+		return m, nil
+	}
+	f := &runForm{image: m.imageName(img.ID)}
+	if f.image == format.Short12(format.StripSha256(img.ID)) {
+		f.image = img.ID // untagged
+	}
+	for i := range f.fields {
+		in := textinput.New()
+		in.CharLimit = 4096
+		in.Prompt = fmt.Sprintf("%-8s ", runFieldLabels[i]+":")
+		in.Placeholder = runFieldPlaceholders[i]
+		f.fields[i] = in
+	}
+	f.resize(m.width)
+	m.runForm = f
+	m.status = ""
+	return m, f.fields[0].Focus()
+}
+
+// resize fits the form's inputs to the terminal width.
+func (f *runForm) resize(width int) {
+	if width <= 0 {
+		return
+	}
+	for i := range f.fields {
+		f.fields[i].Width = max(width-len(f.fields[i].Prompt)-6, 10) // box border and padding
+	}
+}
+
+// move focuses the field delta away from the current one, wrapping.
+func (f *runForm) move(delta int) tea.Cmd {
+	f.fields[f.focus].Blur()
+	f.focus = (f.focus + delta + runFieldCount) % runFieldCount
+	return f.fields[f.focus].Focus()
+}
+
+// values returns what was typed in each field.
+func (f *runForm) values() [runFieldCount]string {
+	var v [runFieldCount]string
+	for i, in := range f.fields {
+		v[i] = in.Value()
+	}
+	return v
+}
+
+// updateRunForm handles key presses while the run form is open.
+func (m model) updateRunForm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	f := *m.runForm
+	switch msg.String() {
+	case "ctrl+c":
+		return m.quit()
+	case "esc":
+		m.runForm = nil
+		m.status = "Cancelled."
+		return m, nil
+	case "tab", "down":
+		cmd := f.move(1)
+		m.runForm = &f
+		return m, cmd
+	case "shift+tab", "up":
+		cmd := f.move(-1)
+		m.runForm = &f
+		return m, cmd
+	case "enter":
+		spec, err := parseRunForm(f.image, f.values())
+		if err != nil {
+			// The form already says what's wrong
+			return m, nil
+		}
+		m.runForm = nil
+		m.status = fmt.Sprintf("Starting a container from %s...", f.image)
+		return m, m.backend.runImage(spec)
+	}
+	return m.updateRunFormInput(msg)
+}
+
+// updateRunFormInput passes msg to the focused field of the run form:
+// typed keys, pastes and cursor blinks.
+func (m model) updateRunFormInput(msg tea.Msg) (tea.Model, tea.Cmd) {
+	f := *m.runForm
+	var cmd tea.Cmd
+	f.fields[f.focus], cmd = f.fields[f.focus].Update(msg)
+	m.runForm = &f
+	return m, cmd
+}
+
+// parseRunForm builds the container the run form asks for, naming the
+// field that can't be used.
+func parseRunForm(image string, v [runFieldCount]string) (runSpec, error) {
+	spec := runSpec{config: container.Config{Image: image}}
+	if s := strings.TrimSpace(v[runFieldName]); s != "" {
+		name, err := parseContainerName(s, "")
+		if err != nil {
+			return runSpec{}, fmt.Errorf("name: %w", err)
+		}
+		spec.name = name
+	}
+
+	ports := strings.Fields(v[runFieldPorts])
+	for _, p := range ports {
+		if _, err := nat.ParsePortSpec(p); err != nil {
+			return runSpec{}, fmt.Errorf("ports: %q is not [ip:][host-port:]container-port[/proto]", p)
+		}
+	}
+	exposed, bindings, err := nat.ParsePortSpecs(ports)
+	if err != nil {
+		return runSpec{}, fmt.Errorf("ports: %w", err)
+	}
+	spec.ports = ports
+	spec.config.ExposedPorts = exposed
+	spec.host.PortBindings = bindings
+
+	env, err := splitWords(v[runFieldEnv])
+	if err != nil {
+		return runSpec{}, fmt.Errorf("env: %w", err)
+	}
+	for _, kv := range env {
+		if k, _, ok := strings.Cut(kv, "="); !ok || k == "" {
+			return runSpec{}, fmt.Errorf("env: %q is not KEY=value", kv)
+		}
+	}
+	spec.config.Env = env
+
+	cmd, err := splitWords(v[runFieldCommand])
+	if err != nil {
+		return runSpec{}, fmt.Errorf("command: %w", err)
+	}
+	spec.config.Cmd = cmd
+	return spec, nil
+}
+
+// splitWords splits s into words the way a shell would for plain
+// arguments: on spaces, keeping quoted runs together and honoring
+// backslash escapes outside single quotes.
+func splitWords(s string) ([]string, error) {
+	var words []string
+	var word strings.Builder
+	inWord := false
+	var quote rune
+	escaped := false
+	for _, r := range s {
+		switch {
+		case escaped:
+			word.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped, inWord = true, true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote, inWord = r, true
+		case r == ' ' || r == '\t':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	switch {
+	case quote != 0:
+		return nil, fmt.Errorf("unclosed %c quote", quote)
+	case escaped:
+		return nil, errors.New("trailing backslash")
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
+
+// runCommandLine renders spec as the equivalent docker command.
+func runCommandLine(spec runSpec) string {
+	parts := []string{"docker run -d"}
+	if spec.name != "" {
+		parts = append(parts, "--name "+spec.name)
+	}
+	for _, p := range spec.ports {
+		parts = append(parts, "-p "+p)
+	}
+	for _, kv := range spec.config.Env {
+		parts = append(parts, "-e "+quoteWord(kv))
+	}
+	parts = append(parts, spec.config.Image)
+	for _, w := range spec.config.Cmd {
+		parts = append(parts, quoteWord(w))
+	}
+	return strings.Join(parts, " ")
+}
+
+// quoteWord single-quotes w when a shell would split or expand it.
+func quoteWord(w string) string {
+	if w != "" && !strings.ContainsAny(w, " \t'\"\\$`*?&|;<>()[]{}!#~") {
+		return w
+	}
+	return "'" + strings.ReplaceAll(w, "'", `'\''`) + "'"
+}
+
+// runFormView renders the open run form as a small box above the footer,
+// with the docker command it amounts to or the field that can't be used.
+func (m model) runFormView() string {
+	f := m.runForm
+	lines := []string{titleStyle.Padding(0).Render("Run " + f.image)}
+	for _, in := range f.fields {
+		lines = append(lines, in.View())
+	}
+	spec, err := parseRunForm(f.image, f.values())
+	if err != nil {
+		lines = append(lines, exitErrorStyle.Render("✗ "+err.Error()))
+	} else {
+		lines = append(lines, helpStyle.Render(runCommandLine(spec)))
+	}
+	lines = append(lines, helpStyle.Render("tab/↑/↓: field • enter: run • esc: cancel"))
+	return menuStyle.Render(strings.Join(lines, "\n"))
+}
+
+// runImage creates and starts a container, like `docker run -d`.
+func (b *backend) runImage(spec runSpec) tea.Cmd {
+	return b.run(func(ctx context.Context, cli docker.Client) tea.Msg {
+		start := time.Now()
+		created, err := cli.ContainerCreate(ctx, &spec.config, &spec.host, nil, nil, spec.name)
+		logCall("ContainerCreate", start, err, "image", spec.config.Image, "name", spec.name)
+		if err != nil {
+			return containerRunMsg{name: spec.name, err: err}
+		}
+		name := spec.name
+		if name == "" {
+			name = format.Short12(created.ID)
+		}
+		start = time.Now()
+		err = cli.ContainerStart(ctx, created.ID, container.StartOptions{})
+		logCall("ContainerStart", start, err, "id", format.Short12(created.ID))
+		if err != nil {
+			err = fmt.Errorf("container %s created but start failed: %w", name, err)
+		}
+		return containerRunMsg{id: created.ID, name: name, err: err}
+	})
+}
+
+// applyRun reports a run and, once the container exists, selects it in
+// the containers table after the reload.
+func (m model) applyRun(msg containerRunMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.status = fmt.Sprintf("Run failed: %v", msg.err)
+	} else {
+		m.status = fmt.Sprintf("Started %s.", msg.name)
+	}
+	if msg.id == "" {
+		return m, nil
+	}
+	m.focusTable(0)
+	m.restoreKey = msg.id
+	return m, m.backend.load(resContainers)
+}
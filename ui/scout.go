@@ -0,0 +1,194 @@
+package ui
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/Antityping/superdocker/docker"
+	"github.com/Antityping/superdocker/format"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// scoutDetectTimeout bounds the check for the scout CLI plugin at startup.
+const scoutDetectTimeout = 10 * time.Second
+
+// scoutSeverities are the severities a scan summary counts, most severe
+// first, as the GitLab report format names them.
+var scoutSeverities = [...]string{"Critical", "High", "Medium", "Low"}
+
+// scoutScan is the outcome of scanning one image, or a scan in flight.
+type scoutScan struct {
+	pending bool
+	counts  [len(scoutSeverities)]int
+	other   int // unknown or informational findings
+	err     error
+}
+
+type scoutDetectedMsg struct {
+	ok bool
+}
+
+type scoutScannedMsg struct {
+	id   string
+	scan scoutScan
+}
+
+// scoutCommand returns the docker CLI invocation for args, pointed at the
+// dashboard's daemon. TLS settings come from the environment as usual.
+func (b *backend) scoutCommand(ctx context.Context, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, "docker", append([]string{"scout"}, args...)...)
+	if b.host != "" {
+		cmd.Env = append(os.Environ(), "DOCKER_HOST="+b.host)
+	}
+	return cmd
+}
+
+// detectScout checks whether the docker CLI with the scout plugin is
+// installed; the scan action is hidden otherwise.
+func (b *backend) detectScout() tea.Cmd {
+	return b.run(func(ctx context.Context, _ docker.Client) tea.Msg {
+		if _, err := exec.LookPath("docker"); err != nil {
+			return scoutDetectedMsg{}
+		}
+		ctx, cancel := context.WithTimeout(ctx, scoutDetectTimeout)
+		defer cancel()
+		start := time.Now()
+		err := b.scoutCommand(ctx, "version").Run()
+		logCall("docker scout version", start, err)
+		return scoutDetectedMsg{ok: err == nil}
+	})
+}
+
+// scanImage scans a local image with `docker scout cves`, counting its
+// vulnerabilities by severity.
+func (b *backend) scanImage(id, ref string) tea.Cmd {
+	return b.run(func(ctx context.Context, _ docker.Client) tea.Msg {
+		var stdout, stderr bytes.Buffer
+		cmd := b.scoutCommand(ctx, "cves", "--format", "gitlab", "local://"+ref)
+		cmd.Stdout, cmd.Stderr = &stdout, &stderr
+		start := time.Now()
+		err := cmd.Run()
+		logCall("docker scout cves", start, err, "id", format.Short12(format.StripSha256(id)))
+		if err != nil {
+			return scoutScannedMsg{id: id, scan: scoutScan{err: scoutError(err, stderr.String())}}
+		}
+		scan, err := parseScoutReport(stdout.Bytes())
+		if err != nil {
+			scan.err = fmt.Errorf("unreadable scout report: %w", err)
+		}
+		return scoutScannedMsg{id: id, scan: scan}
+	})
+}
+
+// scoutError reports a failed scan by the last line scout wrote to stderr,
+// which names the cause, e.g. a missing Docker Hub login.
+func scoutError(err error, stderr string) error {
+	lines := strings.Split(strings.TrimSpace(stderr), "\n")
+	if last := strings.TrimSpace(lines[len(lines)-1]); last != "" {
+		return errors.New(last)
+	}
+	return err
+}
+
+// parseScoutReport counts the findings of a GitLab format scout report.
+func parseScoutReport(data []byte) (scoutScan, error) {
+	var report struct {
+		Vulnerabilities []struct {
+			Severity string `json:"severity"`
+		} `json:"vulnerabilities"`
+	}
+	var scan scoutScan
+	if err := json.Unmarshal(data, &report); err != nil {
+		return scan, err
+	}
+	for _, v := range report.Vulnerabilities {
+		counted := false
+		for i, s := range scoutSeverities {
+			if strings.EqualFold(v.Severity, s) {
+				scan.counts[i]++
+				counted = true
+				break
+			}
+		}
+		if !counted {
+			scan.other++
+		}
+	}
+	return scan, nil
+}
+
+// startScan scans the selected image, unless a scan of it is running.
+func (m model) startScan() (tea.Model, tea.Cmd) {
+	img := m.selectedImage()
+	if img == nil {
+		m.status = "No image selected."
+		return m, nil
+	}
+	name := m.imageName(img.ID)
+	if m.scans[img.ID].pending {
+		m.status = fmt.Sprintf("Already scanning %s.", name)
+		return m, nil
+	}
+	ref := name
+	if name == format.Short12(format.StripSha256(img.ID)) {
+		ref = img.ID // untagged
+	}
+	m.scans[img.ID] = scoutScan{pending: true}
+	m.status = fmt.Sprintf("Scanning %s with docker scout...", name)
+	return m, m.backend.scanImage(img.ID, ref)
+}
+
+// applyScan records a finished scan.
+func (m model) applyScan(msg scoutScannedMsg) (tea.Model, tea.Cmd) {
+	m.scans[msg.id] = msg.scan
+	name := m.imageName(msg.id)
+	if msg.scan.err != nil {
+		m.status = fmt.Sprintf("Scan of %s failed: %v", name, msg.scan.err)
+		return m, nil
+	}
+	m.status = fmt.Sprintf("Scanned %s: %s.", name, msg.scan.summary())
+	return m, nil
+}
+
+// summary renders the counts as plain text, e.g.
+// "0 critical, 2 high, 5 medium, 11 low".
+func (s scoutScan) summary() string {
+	parts := make([]string, len(scoutSeverities))
+	for i, sev := range scoutSeverities {
+		parts[i] = fmt.Sprintf("%d %s", s.counts[i], strings.ToLower(sev))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// renderScan renders the vulnerabilities section of the info panel for an
+// image, highlighting critical and high findings.
+func (m model) renderScan(id string) string {
+	scan, ok := m.scans[id]
+	switch {
+	case !ok:
+		return "Not scanned (V: scan with docker scout)"
+	case scan.pending:
+		return "Scanning..."
+	case scan.err != nil:
+		return "Scan failed: " + scan.err.Error()
+	}
+	lines := make([]string, 0, len(scoutSeverities)+1)
+	for i, sev := range scoutSeverities {
+		line := fmt.Sprintf("%s: %d", sev, scan.counts[i])
+		if i < 2 && scan.counts[i] > 0 {
+			line = exitErrorStyle.Render(line)
+		}
+		lines = append(lines, line)
+	}
+	if scan.other > 0 {
+		lines = append(lines, fmt.Sprintf("Other: %d", scan.other))
+	}
+	return strings.Join(lines, "\n")
+}
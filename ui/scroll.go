@@ -0,0 +1,121 @@
+package ui
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// The table widget keeps its scroll offset to itself, so the model follows
+// it: every cursor move goes through moveUp and moveDown, which apply the
+// widget's own scrolling rules to m.scroll, and clampScroll applies the
+// clamping it does whenever its rows, height or cursor are set: by setRows
+// and, for resizes, after every message. The offset
+// is counted from the first row the widget renders, which is at most one
+// screenful above the cursor.
+
+// navigate moves the cursor of table i for a navigation key of its key map.
+func (m *model) navigate(i int, msg tea.KeyMsg) {
+	t := m.tableAt(i)
+	if !t.Focused() {
+		return
+	}
+	keys, h := t.KeyMap, t.Height()
+	switch {
+	case key.Matches(msg, keys.LineUp):
+		m.moveUp(i, 1)
+	case key.Matches(msg, keys.LineDown):
+		m.moveDown(i, 1)
+	case key.Matches(msg, keys.PageUp):
+		m.moveUp(i, h)
+	case key.Matches(msg, keys.PageDown):
+		m.moveDown(i, h)
+	case key.Matches(msg, keys.HalfPageUp):
+		m.moveUp(i, h/2)
+	case key.Matches(msg, keys.HalfPageDown):
+		m.moveDown(i, h/2)
+	case key.Matches(msg, keys.GotoTop):
+		m.moveUp(i, t.Cursor())
+	case key.Matches(msg, keys.GotoBottom):
+		m.moveDown(i, len(t.Rows()))
+	}
+}
+
+// renderedRows returns the rows table i renders around its cursor, of
+// which the viewport shows one screenful from the scroll offset on.
+func (m *model) renderedRows(i int) (start, end int) {
+	t := m.tableAt(i)
+	cur, h := t.Cursor(), t.Height()
+	if cur >= 0 {
+		start = clampInt(cur-h, 0, cur)
+	}
+	return start, clampInt(cur+h, cur, len(t.Rows()))
+}
+
+// maxScroll returns the largest scroll offset of table i's viewport.
+func (m *model) maxScroll(i int) int {
+	start, end := m.renderedRows(i)
+	return max(0, max(end-start, 1)-m.tableAt(i).Height())
+}
+
+// moveUp moves the cursor of table i up by n rows, like table.MoveUp.
+func (m *model) moveUp(i, n int) {
+	t := m.tableAt(i)
+	start, _ := m.renderedRows(i)
+	h, y := t.Height(), m.scroll[i]
+	cur := clampInt(t.Cursor()-n, 0, len(t.Rows())-1)
+	switch {
+	case start == 0:
+		y = clampInt(clampInt(y, 0, cur), 0, m.maxScroll(i))
+	case start < h:
+		y = clampInt(clampInt(y+n, 0, cur), 0, h)
+	case y >= 1:
+		y = clampInt(y+n, 1, h)
+	}
+	m.scroll[i] = y
+	t.MoveUp(n)
+	m.clampScroll(i)
+}
+
+// moveDown moves the cursor of table i down by n rows, like table.MoveDown.
+func (m *model) moveDown(i, n int) {
+	t := m.tableAt(i)
+	t.MoveDown(n)
+	m.clampScroll(i)
+	start, end := m.renderedRows(i)
+	cur, h, y := t.Cursor(), t.Height(), m.scroll[i]
+	switch {
+	case end == len(t.Rows()) && y > 0:
+		y = clampInt(y-n, 1, h)
+	case cur > (end-start)/2 && y > 0:
+		y = clampInt(y-n, 1, cur)
+	case y > 1:
+		return
+	case cur > y+h-1:
+		y = clampInt(y+1, 0, 1)
+	default:
+		return
+	}
+	m.scroll[i] = clampInt(y, 0, m.maxScroll(i))
+}
+
+// clampScroll drops an offset past the rows table i renders, the way its
+// viewport does each time the table re-renders.
+func (m *model) clampScroll(i int) {
+	start, end := m.renderedRows(i)
+	if m.scroll[i] > max(end-start, 1)-1 {
+		m.scroll[i] = m.maxScroll(i)
+	}
+}
+
+// visibleRows returns the rows of table i on screen.
+func (m *model) visibleRows(i int) (start, end int) {
+	start, end = m.renderedRows(i)
+	top := start + m.scroll[i]
+	return top, clampInt(top+m.tableAt(i).Height(), top, end)
+}
+
+// clampInt returns v limited to [low, high], preferring high when they cross
+// as the table widget does.
+func clampInt(v, low, high int) int {
+	return min(max(v, low), high)
+}
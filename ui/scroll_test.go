@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// numberedRows returns n one-cell rows keyed by their cell.
+func numberedRows(n int) ([]table.Row, []string) {
+	var rows []table.Row
+	var keys []string
+	for i := range n {
+		key := fmt.Sprintf("r%03d", i)
+		rows = append(rows, table.Row{key})
+		keys = append(keys, key)
+	}
+	return rows, keys
+}
+
+// renderedCells returns the rows the containers table draws on screen.
+func renderedCells(m model) []string {
+	var cells []string
+	for _, line := range strings.Split(ansi.Strip(m.containersTable.View()), "\n")[1:] {
+		if line = strings.TrimSpace(line); line != "" {
+			cells = append(cells, line)
+		}
+	}
+	return cells
+}
+
+func TestScrollFollowsTable(t *testing.T) {
+	keys := []tea.KeyMsg{
+		{Type: tea.KeyUp},
+		{Type: tea.KeyDown},
+		{Type: tea.KeyPgUp},
+		{Type: tea.KeyPgDown},
+		{Type: tea.KeyHome},
+		{Type: tea.KeyEnd},
+		{Type: tea.KeyRunes, Runes: []rune("u")},
+		{Type: tea.KeyRunes, Runes: []rune("d")},
+	}
+	for seed := range 20 {
+		r := rand.New(rand.NewSource(int64(seed)))
+		m := model{containersTable: table.New(
+			table.WithColumns([]table.Column{{Title: "ROW", Width: 5}}),
+			table.WithHeight(7),
+			table.WithFocused(true),
+		)}
+		rows, rowKeys := numberedRows(40)
+		m.setRows(0, rows, rowKeys, "")
+
+		for step := range 300 {
+			switch r.Intn(8) {
+			case 0:
+				m.containersTable.SetHeight(2 + r.Intn(14))
+			case 1:
+				// A reload with more or fewer rows
+				rows, rowKeys := numberedRows(r.Intn(50))
+				m.setRows(0, rows, rowKeys, m.selectedRowKey(0))
+			case 2:
+				m.moveCursorTo(0, fmt.Sprintf("r%03d", r.Intn(40)))
+			default:
+				m.navigate(0, keys[r.Intn(len(keys))])
+			}
+			m.clampScroll(0)
+
+			start, end := m.visibleRows(0)
+			var want []string
+			for _, row := range m.containersTable.Rows()[start:end] {
+				want = append(want, row[0])
+			}
+			if got := renderedCells(m); !slices.Equal(got, want) {
+				t.Fatalf("seed %d step %d: table shows %v; tracked %v (cursor %d, height %d)",
+					seed, step, got, want, m.containersTable.Cursor(), m.containersTable.Height())
+			}
+		}
+	}
+}
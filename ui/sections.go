@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// infoSection is one foldable part of the info panel.
+type infoSection struct {
+	title string
+	body  string
+}
+
+var sectionCursorStyle = lipgloss.NewStyle().
+	Bold(true).
+	Foreground(lipgloss.Color("170"))
+
+// renderSections lays out the info panel sections for table i, folding the
+// ones collapsed for that resource type and marking the section cursor.
+func (m model) renderSections(i int, sections []infoSection) string {
+	cur := min(m.sectionCursor[i], len(sections)-1)
+	parts := make([]string, 0, len(sections))
+	for j, s := range sections {
+		marker := "▾ "
+		folded := m.collapsed[i][s.title]
+		if folded {
+			marker = "▸ "
+		}
+		header := marker + s.title
+		if j == cur {
+			header = sectionCursorStyle.Render(header)
+		}
+		if folded {
+			parts = append(parts, header)
+			continue
+		}
+		parts = append(parts, header+"\n"+s.body)
+	}
+	return strings.Join(parts, "\n")
+}
+
+// sectionTitles returns the titles of the info panel sections of the
+// focused table's selected resource.
+func (m model) sectionTitles() []string {
+	var sections []infoSection
+	switch m.focusIndex {
+	case 1:
+		sections = m.imageInfoSections()
+	case 2:
+		sections = m.volumeInfoSections()
+	case 3:
+		sections = m.networkInfoSections()
+	case 4:
+		sections = m.serviceInfoSections()
+	default:
+		sections = m.containerInfoSections()
+	}
+	titles := make([]string, len(sections))
+	for j, s := range sections {
+		titles[j] = s.title
+	}
+	return titles
+}
+
+// moveSection moves the section cursor of the focused table by delta.
+func (m *model) moveSection(delta int) {
+	n := len(m.sectionTitles())
+	if n == 0 {
+		return
+	}
+	i := m.focusIndex
+	m.sectionCursor[i] = (min(m.sectionCursor[i], n-1) + delta + n) % n
+}
+
+// toggleSection folds or unfolds the section under the cursor. Folding is
+// remembered per resource type, so it carries over to other resources.
+func (m *model) toggleSection() {
+	titles := m.sectionTitles()
+	if len(titles) == 0 {
+		return
+	}
+	i := m.focusIndex
+	title := titles[min(m.sectionCursor[i], len(titles)-1)]
+	if m.collapsed[i] == nil {
+		m.collapsed[i] = map[string]bool{}
+	}
+	m.collapsed[i][title] = !m.collapsed[i][title]
+}
@@ -0,0 +1,151 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Antityping/superdocker/format"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/docker/docker/api/types/swarm"
+)
+
+// swarmManager reports whether the daemon is a Swarm manager, the only
+// kind of node that can list services.
+func (m model) swarmManager() bool {
+	return m.hostInfo != nil && m.hostInfo.Swarm.ControlAvailable
+}
+
+// tableVisible reports whether table i is shown. The services table only
+// exists on Swarm managers, so standalone daemons keep the four tables.
+func (m model) tableVisible(i int) bool {
+	return i != 4 || m.swarmManager()
+}
+
+// serviceMode names how a service schedules its tasks, like the MODE
+// column of `docker service ls`.
+func serviceMode(s swarm.Service) string {
+	switch mode := s.Spec.Mode; {
+	case mode.Replicated != nil:
+		return "replicated"
+	case mode.Global != nil:
+		return "global"
+	case mode.ReplicatedJob != nil:
+		return "replicated job"
+	case mode.GlobalJob != nil:
+		return "global job"
+	}
+	return "-"
+}
+
+// serviceReplicas renders running/desired tasks, e.g. "2/3". Without the
+// task counts, which older daemons don't send, the desired count of a
+// replicated service is still known.
+func serviceReplicas(s swarm.Service) string {
+	if st := s.ServiceStatus; st != nil {
+		return fmt.Sprintf("%d/%d", st.RunningTasks, st.DesiredTasks)
+	}
+	if r := s.Spec.Mode.Replicated; r != nil && r.Replicas != nil {
+		return fmt.Sprintf("?/%d", *r.Replicas)
+	}
+	return "-"
+}
+
+// serviceImage returns the image of a service's tasks without the digest
+// the manager pins it to.
+func serviceImage(s swarm.Service) string {
+	if s.Spec.TaskTemplate.ContainerSpec == nil {
+		return "-"
+	}
+	image, _, _ := strings.Cut(s.Spec.TaskTemplate.ContainerSpec.Image, "@")
+	return format.OrDash(image)
+}
+
+// servicePorts lists the published ports of a service, e.g. "8080->80/tcp".
+func servicePorts(s swarm.Service) string {
+	var ps []string
+	for _, p := range s.Endpoint.Ports {
+		entry := fmt.Sprintf("%d/%s", p.TargetPort, p.Protocol)
+		if p.PublishedPort != 0 {
+			entry = fmt.Sprintf("%d->%s", p.PublishedPort, entry)
+		}
+		ps = append(ps, entry)
+	}
+	if len(ps) == 0 {
+		return "-"
+	}
+	return strings.Join(ps, ", ")
+}
+
+// setServiceRows rebuilds the services table, keeping the selected service.
+func (m *model) setServiceRows() {
+	selectedID := m.selectedRowKey(4)
+	sRows := []table.Row{}
+	keys := []string{}
+	visible := filterItems(m.filter, m.services, func(s swarm.Service) (map[string]string, []string) {
+		return s.Spec.Labels, []string{s.Spec.Name, s.ID, serviceImage(s)}
+	})
+	for _, s := range visible {
+		name := format.TrimTo(s.Spec.Name, 22)
+		image := format.TrimTo(serviceImage(s), 30)
+		sRows = append(sRows, table.Row{name, serviceMode(s), serviceReplicas(s), image, ageCell(s.CreatedAt)})
+		keys = append(keys, s.ID)
+	}
+	m.setRows(4, sRows, keys, selectedID)
+}
+
+// selectedService returns the service under the cursor, or nil.
+func (m model) selectedService() *swarm.Service {
+	i, ok := m.index[4][m.selectedRowKey(4)]
+	if !ok || i >= len(m.services) {
+		return nil
+	}
+	return &m.services[i]
+}
+
+func (m model) renderSelectedServiceInfo() string {
+	sections := m.serviceInfoSections()
+	if sections == nil {
+		return "No service selected."
+	}
+	return m.renderSections(4, sections)
+}
+
+// serviceInfoSections builds the info panel sections of the selected
+// service, or nil when none is selected.
+func (m model) serviceInfoSections() []infoSection {
+	s := m.selectedService()
+	if s == nil {
+		return nil
+	}
+	return []infoSection{
+		{"General", fmt.Sprintf("Name: %s\nID: %s\nMode: %s\nReplicas: %s\nImage: %s\nCreated: %s\nUpdated: %s",
+			s.Spec.Name, format.Short12(s.ID), serviceMode(*s), serviceReplicas(*s), serviceImage(*s),
+			m.timeFormat.renderCreated(s.CreatedAt), m.timeFormat.format(s.UpdatedAt))},
+		{"Ports & labels", fmt.Sprintf("Ports: %s\nLabels: %s", servicePorts(*s), format.JoinKV(s.Spec.Labels))},
+	}
+}
+
+// serviceDetail renders the detail screen of a service from the listed
+// data, which the list call already returns in full.
+func serviceDetail(s swarm.Service, tf timeFormat) tea.Cmd {
+	return func() tea.Msg {
+		var d detailBuilder
+		d.section("General")
+		d.field("Name", s.Spec.Name)
+		d.field("ID", s.ID)
+		d.field("Mode", serviceMode(s))
+		d.field("Replicas", serviceReplicas(s))
+		d.field("Image", serviceImage(s))
+		d.field("Created", tf.format(s.CreatedAt))
+		d.field("Updated", tf.format(s.UpdatedAt))
+		if u := s.UpdateStatus; u != nil {
+			d.field("Update", fmt.Sprintf("%s %s", u.State, u.Message))
+		}
+		d.section("Ports")
+		d.field("Ports", servicePorts(s))
+		d.section("Labels")
+		d.list("Labels", format.SortedKV(s.Spec.Labels))
+		return detailLoadedMsg{title: "Service " + s.Spec.Name, body: d.String(), raw: inspectJSON(s)}
+	}
+}
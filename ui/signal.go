@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Antityping/superdocker/docker"
+	"github.com/Antityping/superdocker/format"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// commonSignals are the signals offered by the signal menu.
+var commonSignals = []struct {
+	key, name string
+}{
+	{"h", "SIGHUP"},
+	{"1", "SIGUSR1"},
+	{"2", "SIGUSR2"},
+	{"i", "SIGINT"},
+	{"t", "SIGTERM"},
+	{"k", "SIGKILL"},
+}
+
+// signalName matches the forms the daemon accepts: SIGHUP, HUP or 1.
+var signalName = regexp.MustCompile(`^((SIG)?[A-Z][A-Z0-9+-]*|[0-9]+)$`)
+
+// confirmSignal offers common signals to send to the selected container,
+// or a prompt to type another.
+func (m model) confirmSignal() (tea.Model, tea.Cmd) {
+	c := m.selectedContainer()
+	if c == nil {
+		m.status = "No container selected."
+		return m, nil
+	}
+	if c.State != "running" {
+		m.status = fmt.Sprintf("%s is not running.", containerName(*c))
+		return m, nil
+	}
+	name := containerName(*c)
+	choices := make([]confirmChoice, 0, len(commonSignals)+1)
+	for _, s := range commonSignals {
+		choices = append(choices, confirmChoice{
+			key:    s.key,
+			label:  s.name,
+			status: fmt.Sprintf("Sending %s to %s...", s.name, name),
+			id:     c.ID,
+			cmd:    m.backend.signalContainer(c.ID, name, s.name),
+		})
+	}
+	id := c.ID
+	choices = append(choices, confirmChoice{
+		key:   "s",
+		label: "other...",
+		open: func(m model) (tea.Model, tea.Cmd) {
+			m.signalID = id
+			return m.openPrompt(promptSignal, fmt.Sprintf("Signal for %s: ", name), "SIG")
+		},
+	})
+	return m.askPick(fmt.Sprintf("Send a signal to %s:", name), choices...)
+}
+
+// parseSignal checks a typed signal name, upper-casing it. Names the
+// daemon doesn't know are left for it to reject.
+func parseSignal(s string) (string, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	if s == "" || s == "SIG" {
+		return "", errors.New("enter a signal such as SIGHUP, HUP or 1")
+	}
+	if !signalName.MatchString(s) {
+		return "", fmt.Errorf("%q is not a signal name or number", s)
+	}
+	return s, nil
+}
+
+// signalContainer sends signal to a running container, like `docker kill
+// --signal`.
+func (b *backend) signalContainer(id, name, signal string) tea.Cmd {
+	return b.run(func(ctx context.Context, cli docker.Client) tea.Msg {
+		done := actionDoneMsg{id: id, action: "Sending " + signal, reload: resContainers}
+		start := time.Now()
+		err := cli.ContainerKill(ctx, id, signal)
+		logCall("ContainerKill", start, err, "id", format.Short12(id), "signal", signal)
+		if err != nil {
+			done.err = err
+			return done
+		}
+		done.status = fmt.Sprintf("Sent %s to %s.", signal, name)
+		return done
+	})
+}
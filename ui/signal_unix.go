@@ -0,0 +1,31 @@
+//go:build !windows
+
+package ui
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// watchRefreshSignal reloads the dashboard whenever the process receives
+// SIGUSR1, e.g. `kill -USR1 <pid>` from a build hook or file watcher, until
+// ctx is canceled.
+func watchRefreshSignal(ctx context.Context, p *tea.Program) {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGUSR1)
+	go func() {
+		defer signal.Stop(ch)
+		for {
+			select {
+			case <-ch:
+				p.Send(refreshMsg{})
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
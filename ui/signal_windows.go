@@ -0,0 +1,12 @@
+//go:build windows
+
+package ui
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// watchRefreshSignal is a no-op on Windows, which has no SIGUSR1.
+func watchRefreshSignal(ctx context.Context, p *tea.Program) {}
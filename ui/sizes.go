@@ -0,0 +1,64 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Antityping/superdocker/docker"
+	"github.com/Antityping/superdocker/format"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/docker/docker/api/types/container"
+)
+
+// containerSize is the disk usage reported by `docker ps -s`.
+type containerSize struct {
+	rw     int64 // writable layer
+	rootFs int64 // writable layer plus the image (virtual size)
+}
+
+type containerSizesMsg struct {
+	sizes map[string]containerSize
+	err   error
+}
+
+// loadContainerSizes lists containers with sizes. The daemon walks every
+// writable layer to answer this, so it is only run on request.
+func (b *backend) loadContainerSizes() tea.Cmd {
+	return b.run(listContainerSizes)
+}
+
+func listContainerSizes(ctx context.Context, cli docker.Client) tea.Msg {
+	start := time.Now()
+	list, err := cli.ContainerList(ctx, container.ListOptions{All: true, Size: true})
+	logCall("ContainerList", start, err)
+	if err != nil {
+		return containerSizesMsg{err: err}
+	}
+	sizes := make(map[string]containerSize, len(list))
+	for _, c := range list {
+		sizes[c.ID] = containerSize{rw: c.SizeRw, rootFs: c.SizeRootFs}
+	}
+	return containerSizesMsg{sizes: sizes}
+}
+
+// formatContainerSize renders a size cell like `docker ps -s`.
+func formatContainerSize(s containerSize) string {
+	return fmt.Sprintf("%s (v %s)", format.HumanizeBytes(s.rw), format.HumanizeBytes(s.rootFs))
+}
+
+// sizeSummary names the container with the largest writable layer.
+func sizeSummary(sizes map[string]containerSize) string {
+	var total, largest int64
+	largestID := ""
+	for id, s := range sizes {
+		total += s.rw
+		if s.rw > largest {
+			largest, largestID = s.rw, id
+		}
+	}
+	if largestID == "" {
+		return "Container sizes updated."
+	}
+	return fmt.Sprintf("Writable layers total %s; largest is %s (%s).", format.HumanizeBytes(total), format.Short12(largestID), format.HumanizeBytes(largest))
+}
@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Antityping/superdocker/docker"
+)
+
+// Snapshot lists the daemon's resources once and writes the tables to w as
+// plain text, with the columns the dashboard shows, for pipelines and CI
+// logs where the interactive dashboard can't run.
+func Snapshot(opts Options, w io.Writer) error {
+	if opts.DebugPath != "" {
+		closeLog, err := enableDebugLog(opts.DebugPath)
+		if err != nil {
+			return fmt.Errorf("--debug: %w", err)
+		}
+		defer closeLog()
+	}
+
+	prefs, err := loadPrefs()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: ignoring preferences: %v\n", err)
+	}
+	if _, err := parseTimeFormat(prefs.TimeFormat); err != nil {
+		prefs.TimeFormat = ""
+	}
+
+	b, err := newBackend(opts.Host)
+	if err != nil {
+		if opts.Host != "" {
+			return fmt.Errorf("cannot create Docker client for %s: %w", opts.Host, err)
+		}
+		return fmt.Errorf("cannot create Docker client: %w", err)
+	}
+	defer func() {
+		if err := b.close(); err != nil {
+			debugLog.Error("closing docker client failed", "err", err)
+		}
+	}()
+
+	m := initialModel(defaultLayout(), prefs, 0)
+	m.backend = b
+	kinds := allResources
+	start := time.Now()
+	info, err := b.cli.Info(b.ctx)
+	logCall("Info", start, err)
+	if err == nil {
+		m.hostInfo = &info
+		if m.swarmManager() {
+			kinds |= resServices
+		}
+	}
+	msg := listResources(b.ctx, b.cli, kinds, b.listFilterSet())
+	if msg.err != nil && msg.kinds == 0 {
+		return docker.ExplainError(msg.err)
+	}
+	m.applyLoaded(msg)
+
+	var sections []string
+	titles := m.tableTitles()
+	for i := range tableNames {
+		if kinds&(resourceSet(1)<<i) == 0 {
+			continue
+		}
+		if msg.kinds&(resourceSet(1)<<i) == 0 {
+			sections = append(sections, fmt.Sprintf("%s\n(could not list: %v)\n", titles[i], docker.ExplainError(msg.err)))
+			continue
+		}
+		headers, rows := m.plainTable(i)
+		sections = append(sections, fmt.Sprintf("%s (%d)\n%s", titles[i], len(rows), renderPlainTable(headers, rows, false)))
+	}
+	_, err = io.WriteString(w, strings.Join(sections, "\n"))
+	return err
+}
@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/table"
+)
+
+// sortArrow marks the header of the column the containers table is sorted
+// by; the stats sorts put the busiest first.
+const sortArrow = "▼"
+
+// sortKeys name the stats sorts in the preferences file.
+var sortKeys = map[statsSort]string{sortCPU: "cpu", sortMem: "mem"}
+
+// sortColumns are the titles of the columns the stats sorts order by.
+var sortColumns = map[statsSort]string{sortCPU: "CPU %", sortMem: "Mem"}
+
+// parseSortKey resolves a sort saved in the preferences file, falling back
+// to no sort for anything unknown.
+func parseSortKey(s string) statsSort {
+	for sort, key := range sortKeys {
+		if key == s {
+			return sort
+		}
+	}
+	return sortNone
+}
+
+// setSort orders the containers table by s, marks its column and saves
+// the choice so refreshes and the next launch keep the order.
+func (m *model) setSort(s statsSort) {
+	m.sortBy = s
+	if m.prefs.Sort == nil {
+		m.prefs.Sort = map[string]string{}
+	}
+	if key, ok := sortKeys[s]; ok {
+		m.prefs.Sort[tableNames[0]] = key
+	} else {
+		delete(m.prefs.Sort, tableNames[0])
+	}
+	m.applyColumns(0)
+	m.setContainerRows()
+	m.status = fmt.Sprintf("Containers sorted by: %s", s)
+	if s != sortNone && !m.statsOn {
+		m.status += " (live stats are off; C: turn on)"
+	}
+	if err := savePrefs(m.prefs); err != nil {
+		m.status = fmt.Sprintf("Could not save preferences: %v", err)
+	}
+}
+
+// markSortColumn appends the sort arrow to the title of the column table i
+// is sorted by, if any.
+func (m model) markSortColumn(i int, cols []table.Column) []table.Column {
+	title, ok := sortColumns[m.sortBy]
+	if i != 0 || !ok {
+		return cols
+	}
+	for j := range cols {
+		if cols[j].Title == title {
+			cols[j].Title += " " + sortArrow
+		}
+	}
+	return cols
+}
@@ -0,0 +1,119 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Antityping/superdocker/format"
+)
+
+// historyLen is how many stats samples are kept per container, two minutes
+// at the default stats interval.
+const historyLen = 60
+
+// sparkBlocks are the eight bar heights a sparkline is drawn with.
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// sampleRing is a fixed-size ring buffer of the most recent samples.
+type sampleRing struct {
+	vals [historyLen]float64
+	next int
+	n    int
+}
+
+func (r *sampleRing) push(v float64) {
+	r.vals[r.next] = v
+	r.next = (r.next + 1) % historyLen
+	r.n = min(r.n+1, historyLen)
+}
+
+// values returns the samples oldest first.
+func (r *sampleRing) values() []float64 {
+	out := make([]float64, 0, r.n)
+	for i := historyLen - r.n; i < historyLen; i++ {
+		out = append(out, r.vals[(r.next+i)%historyLen])
+	}
+	return out
+}
+
+// statsHistory is the recent CPU and memory trend of one container.
+type statsHistory struct {
+	cpu sampleRing
+	mem sampleRing
+}
+
+// recordHistory appends a stats sample to each container's history and
+// drops the history of containers that are no longer sampled.
+func (m *model) recordHistory(stats map[string]containerStats) {
+	for id := range m.history {
+		if _, ok := stats[id]; !ok {
+			delete(m.history, id)
+		}
+	}
+	for id, s := range stats {
+		h, ok := m.history[id]
+		if !ok {
+			h = &statsHistory{}
+			m.history[id] = h
+		}
+		h.cpu.push(s.cpuPercent)
+		h.mem.push(float64(s.memUsage))
+	}
+}
+
+// sparkline draws the last width samples scaled to top, or to the largest
+// sample when top is 0.
+func sparkline(vals []float64, width int, top float64) string {
+	if width <= 0 || len(vals) == 0 {
+		return ""
+	}
+	if len(vals) > width {
+		vals = vals[len(vals)-width:]
+	}
+	if top <= 0 {
+		for _, v := range vals {
+			top = max(top, v)
+		}
+	}
+	var b strings.Builder
+	for _, v := range vals {
+		i := 0
+		if top > 0 {
+			i = int(v / top * float64(len(sparkBlocks)-1))
+		}
+		b.WriteRune(sparkBlocks[min(max(i, 0), len(sparkBlocks)-1)])
+	}
+	return b.String()
+}
+
+// renderHistory renders the CPU and memory sparklines of a container for
+// the info panel, or "" before the second sample.
+func (m model) renderHistory(id string, width int) string {
+	h, ok := m.history[id]
+	if !ok || h.cpu.n < 2 {
+		return ""
+	}
+	cpu, mem := h.cpu.values(), h.mem.values()
+	var topCPU, topMem float64
+	for i := range cpu {
+		topCPU, topMem = max(topCPU, cpu[i]), max(topMem, mem[i])
+	}
+	// Scale CPU to at least 1% so an idle container's noise stays flat
+	w := width - len("CPU  ")
+	return fmt.Sprintf("CPU  %s\n     peak %.1f%%\nMem  %s\n     peak %s",
+		sparkline(cpu, w, max(topCPU, 1)), topCPU,
+		sparkline(mem, w, 0), format.HumanizeBytes(int64(topMem)))
+}
+
+// infoPanelWidth is the usable width of the info panel, with a fallback
+// before the first window size is known.
+func (m model) infoPanelWidth() int {
+	if m.width == 0 {
+		return 80
+	}
+	_, rw := m.paneWidths()
+	if m.compact {
+		return rw - 1
+	}
+	return rw - 2
+}
@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// stackedView renders the single-column layout used on narrow terminals:
+// a strip naming the tables, the focused table at full width and its
+// info panel below, unless it is hidden. Tab moves between the tables as
+// usual. Compact mode drops the borders as it does beside the panel.
+func (m model) stackedView() string {
+	inner := m.width
+	if !m.compact {
+		inner -= 2 // border
+	}
+	style := titleStyle
+	if m.compact {
+		style = compactTitleStyle
+	}
+	t := m.tableAt(m.focusIndex)
+	t.SetWidth(inner)
+	tables := lipgloss.JoinVertical(lipgloss.Left,
+		clipWidth(m.tableStrip(), inner),
+		clipWidth(style.Render("▸ "+m.tableTitles()[m.focusIndex]), inner),
+		clipWidth(t.View(), inner),
+	)
+
+	if m.layout.hideInfo {
+		if m.compact {
+			return tables
+		}
+		return baseStyle.Width(inner).Render(tables)
+	}
+	infoTitle, infoBody := m.infoTitleAndBody()
+	info := lipgloss.NewStyle().Width(inner).Render(infoTitle + "\n" + infoBody)
+	if m.compact {
+		return lipgloss.JoinVertical(lipgloss.Left, tables, clipWidth(info, inner))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left,
+		baseStyle.Width(inner).Render(tables),
+		baseStyle.Width(inner).Render(clipWidth(info, inner)),
+	)
+}
+
+// tableStrip names the tables on one line, the focused one highlighted, so
+// the hidden ones stay discoverable in the single-column layout.
+func (m model) tableStrip() string {
+	names := make([]string, 0, len(tableNames))
+	for i, name := range tableNames {
+		switch {
+		case !m.tableVisible(i):
+		case i == m.focusIndex:
+			names = append(names, compactTitleStyle.Render(name))
+		default:
+			names = append(names, dividerStyle.Render(name))
+		}
+	}
+	return strings.Join(names, dividerStyle.Render(" │ ")) + dividerStyle.Render("  (Tab)")
+}
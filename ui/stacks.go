@@ -0,0 +1,161 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Antityping/superdocker/format"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/docker/docker/api/types/container"
+)
+
+// Labels Compose sets on the containers it creates.
+const (
+	composeProjectLabel    = "com.docker.compose.project"
+	composeServiceLabel    = "com.docker.compose.service"
+	composeWorkingDirLabel = "com.docker.compose.project.working_dir"
+)
+
+// stackKeyPrefix marks the row keys of stack header rows. Container IDs are
+// hex, so the keys never collide, and the cursor stays on a header across
+// refreshes like on any other row.
+const stackKeyPrefix = "stack:"
+
+// stackRows renders containers grouped under one header row per Compose
+// project, projects sorted by name, followed by containers not in a project.
+func (m model) stackRows(cs []container.Summary, top string) ([]table.Row, []string) {
+	byProject := map[string][]container.Summary{}
+	var loose []container.Summary
+	for _, c := range cs {
+		if p := c.Labels[composeProjectLabel]; p != "" {
+			byProject[p] = append(byProject[p], c)
+		} else {
+			loose = append(loose, c)
+		}
+	}
+	projects := make([]string, 0, len(byProject))
+	for p := range byProject {
+		projects = append(projects, p)
+	}
+	sort.Strings(projects)
+
+	rows := []table.Row{}
+	keys := []string{}
+	for _, p := range projects {
+		members := byProject[p]
+		running := 0
+		for _, c := range members {
+			if c.State == "running" {
+				running++
+			}
+		}
+		header := make(table.Row, len(defaultColumns[0]))
+		header[0] = treeHeaderPrefix + "stack"
+		header[1] = fmt.Sprintf("%s (%d/%d up)", p, running, len(members))
+		rows = append(rows, header)
+		keys = append(keys, stackKeyPrefix+p)
+		for i, c := range members {
+			branch := "├─ "
+			if i == len(members)-1 {
+				branch = "└─ "
+			}
+			row := m.containerRow(c, top)
+			row[1] = format.TrimTo(branch+containerService(c), 25)
+			rows = append(rows, row)
+			keys = append(keys, c.ID)
+		}
+	}
+	for _, c := range loose {
+		rows = append(rows, m.containerRow(c, top))
+		keys = append(keys, c.ID)
+	}
+	return rows, keys
+}
+
+// containerService names a Compose container by its service, falling back
+// to the container name.
+func containerService(c container.Summary) string {
+	if s := c.Labels[composeServiceLabel]; s != "" {
+		return s
+	}
+	return containerName(c)
+}
+
+// selectedStack reports whether a stack header is selected in the
+// containers table and returns its project.
+func (m model) selectedStack() (string, bool) {
+	return strings.CutPrefix(m.selectedRowKey(0), stackKeyPrefix)
+}
+
+// stackContainers returns the loaded containers of a Compose project.
+func (m model) stackContainers(project string) []container.Summary {
+	var cs []container.Summary
+	for _, c := range m.containers {
+		if c.Labels[composeProjectLabel] == project {
+			cs = append(cs, c)
+		}
+	}
+	return cs
+}
+
+// renderStackInfo summarizes a Compose project in the info panel.
+func (m model) renderStackInfo(project string) string {
+	cs := m.stackContainers(project)
+	if len(cs) == 0 {
+		return "No container selected."
+	}
+	dir := "-"
+	services := make([]string, 0, len(cs))
+	for _, c := range cs {
+		if d := c.Labels[composeWorkingDirLabel]; d != "" {
+			dir = d
+		}
+		services = append(services, fmt.Sprintf("%s (%s)", containerService(c), format.OrDash(c.State)))
+	}
+	sort.Strings(services)
+	return fmt.Sprintf("Project: %s\nWorking dir: %s\nContainers: %d\nServices: %s\n\nA: stop/start/restart the stack",
+		project, dir, len(cs), strings.Join(services, ", "),
+	)
+}
+
+// confirmStackAction asks whether to stop, start or restart every
+// container of a Compose project.
+func (m model) confirmStackAction(project string) (tea.Model, tea.Cmd) {
+	var all, running, stopped []string
+	for _, c := range m.stackContainers(project) {
+		all = append(all, c.ID)
+		switch {
+		case c.State == "running":
+			running = append(running, c.ID)
+		case stoppedStates[c.State]:
+			stopped = append(stopped, c.ID)
+		}
+	}
+	if len(all) == 0 {
+		m.status = "No containers in stack."
+		return m, nil
+	}
+	var choices []confirmChoice
+	if len(running) > 0 {
+		choices = append(choices, confirmChoice{
+			key: "s", label: fmt.Sprintf("stop %d", len(running)),
+			status: fmt.Sprintf("Stopping stack %s...", project),
+			cmd:    m.backend.bulkContainerAction("Stop stack", "stopped", running, stopContainer),
+		})
+	}
+	if len(stopped) > 0 {
+		choices = append(choices, confirmChoice{
+			key: "u", label: fmt.Sprintf("start %d", len(stopped)),
+			status: fmt.Sprintf("Starting stack %s...", project),
+			cmd:    m.backend.bulkContainerAction("Start stack", "started", stopped, startContainer),
+		})
+	}
+	choices = append(choices, confirmChoice{
+		key: "r", label: fmt.Sprintf("restart %d", len(all)),
+		status: fmt.Sprintf("Restarting stack %s...", project),
+		cmd:    m.backend.bulkContainerAction("Restart stack", "restarted", all, restartContainer),
+	})
+	return m.askPick(fmt.Sprintf("Act on stack %s?", project), choices...)
+}
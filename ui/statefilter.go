@@ -0,0 +1,56 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// stateFilterCycle is the order the state filter key steps through;
+// "" lists containers in every state.
+var stateFilterCycle = []string{"", "running", "exited", "paused", "restarting", "created"}
+
+// stateFilterOf returns the state the containers' daemon filter is limited
+// to by a single status term, or "" when it has none or several.
+func stateFilterOf(terms string) string {
+	state := ""
+	for _, term := range strings.Fields(terms) {
+		if v, ok := strings.CutPrefix(term, "status="); ok {
+			if state != "" {
+				return ""
+			}
+			state = v
+		}
+	}
+	return state
+}
+
+// withStateFilter replaces the status terms of a daemon filter with one
+// for state, or drops them for "".
+func withStateFilter(terms, state string) string {
+	var kept []string
+	for _, term := range strings.Fields(terms) {
+		if !strings.HasPrefix(term, "status=") {
+			kept = append(kept, term)
+		}
+	}
+	if state != "" {
+		kept = append(kept, "status="+state)
+	}
+	return strings.Join(kept, " ")
+}
+
+// cycleStateFilter limits the containers table to the next state in
+// stateFilterCycle, through the daemon filter so it shows in the title
+// and combines with the other terms typed there.
+func (m model) cycleStateFilter() (tea.Model, tea.Cmd) {
+	current := stateFilterOf(m.daemonFilters[0])
+	next := stateFilterCycle[0]
+	for i, s := range stateFilterCycle {
+		if s == current {
+			next = stateFilterCycle[(i+1)%len(stateFilterCycle)]
+			break
+		}
+	}
+	return m.applyDaemonFilter(0, withStateFilter(m.daemonFilters[0], next))
+}
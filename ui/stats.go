@@ -0,0 +1,307 @@
+package ui
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Antityping/superdocker/docker"
+	"github.com/Antityping/superdocker/format"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/docker/docker/api/types/container"
+)
+
+// statsInterval is how often live stats are sampled for running containers.
+const statsInterval = 2 * time.Second
+
+// statsSort selects which stats column, if any, orders the containers table.
+type statsSort int
+
+const (
+	sortNone statsSort = iota
+	sortCPU
+	sortMem
+)
+
+func (s statsSort) String() string {
+	switch s {
+	case sortCPU:
+		return "CPU"
+	case sortMem:
+		return "memory"
+	default:
+		return "none"
+	}
+}
+
+// containerStats is the computed resource usage for one container.
+type containerStats struct {
+	cpuPercent float64
+	memUsage   uint64
+	memLimit   uint64
+	// raw counters kept to compute the CPU delta on the next sample
+	cpuTotal    uint64
+	systemTotal uint64
+	// network byte counters summed over all interfaces, and the rates
+	// since the previous sample; hasNet is false for containers without
+	// networks of their own, e.g. in host mode
+	hasNet           bool
+	netRated         bool
+	rxBytes, txBytes uint64
+	rxRate, txRate   float64
+	// block I/O byte counters and rates, the same way; hasBlkio is false
+	// where the platform doesn't report them
+	hasBlkio              bool
+	blkioRated            bool
+	readBytes, writeBytes uint64
+	readRate, writeRate   float64
+	// when the daemon took the sample, for the rates
+	read time.Time
+}
+
+// statsTickMsg and statsLoadedMsg carry the generation of the sampling
+// loop that sent them: toggling collection off and on again starts a new
+// loop, and the old one's messages are dropped.
+type statsTickMsg struct {
+	gen int
+}
+
+type statsLoadedMsg struct {
+	gen   int
+	stats map[string]containerStats
+}
+
+func statsTick(gen int) tea.Cmd {
+	return tea.Tick(statsInterval, func(time.Time) tea.Msg { return statsTickMsg{gen: gen} })
+}
+
+// toggleStats turns live stats collection on or off. It is off by default
+// since every sample costs the daemon a stats call per container.
+func (m model) toggleStats() (tea.Model, tea.Cmd) {
+	m.statsOn = !m.statsOn
+	m.statsGen++
+	if !m.statsOn {
+		m.stats = map[string]containerStats{}
+		m.history = map[string]*statsHistory{}
+		m.setContainerRows()
+		m.status = "Live stats: off"
+		return m, nil
+	}
+	m.status = "Live stats: on (visible running containers)"
+	return m, m.backend.pollStats(m.statsGen, m.visibleRunningContainerIDs(), m.stats)
+}
+
+// pollStats samples the given running containers once. CPU usage is
+// computed against the previous sample, so the first tick only primes the
+// counters.
+func (b *backend) pollStats(gen int, ids []string, prev map[string]containerStats) tea.Cmd {
+	return b.run(func(ctx context.Context, cli docker.Client) tea.Msg {
+		out := make(map[string]containerStats, len(ids))
+		if len(ids) == 0 {
+			return statsLoadedMsg{gen: gen, stats: out}
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, statsInterval)
+		defer cancel()
+
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		for _, id := range ids {
+			wg.Add(1)
+			go func(id string) {
+				defer wg.Done()
+				start := time.Now()
+				resp, err := cli.ContainerStatsOneShot(ctx, id)
+				logCall("ContainerStatsOneShot", start, err, "id", format.Short12(id))
+				if err != nil {
+					return
+				}
+				defer resp.Body.Close()
+				var s container.StatsResponse
+				if err := json.NewDecoder(resp.Body).Decode(&s); err != nil {
+					return
+				}
+				cs := computeStats(s, prev[id])
+				mu.Lock()
+				out[id] = cs
+				mu.Unlock()
+			}(id)
+		}
+		wg.Wait()
+		return statsLoadedMsg{gen: gen, stats: out}
+	})
+}
+
+// computeStats derives CPU% and memory usage the same way `docker stats` does.
+func computeStats(s container.StatsResponse, prev containerStats) containerStats {
+	cs := containerStats{
+		cpuTotal:    s.CPUStats.CPUUsage.TotalUsage,
+		systemTotal: s.CPUStats.SystemUsage,
+		memLimit:    s.MemoryStats.Limit,
+	}
+
+	// Prefer the daemon's own previous sample when it sent one
+	preCPU, preSystem := prev.cpuTotal, prev.systemTotal
+	if s.PreCPUStats.SystemUsage != 0 {
+		preCPU, preSystem = s.PreCPUStats.CPUUsage.TotalUsage, s.PreCPUStats.SystemUsage
+	}
+	online := float64(s.CPUStats.OnlineCPUs)
+	if online == 0 {
+		online = float64(len(s.CPUStats.CPUUsage.PercpuUsage))
+	}
+	if preSystem != 0 && cs.systemTotal > preSystem && cs.cpuTotal >= preCPU {
+		cpuDelta := float64(cs.cpuTotal - preCPU)
+		sysDelta := float64(cs.systemTotal - preSystem)
+		cs.cpuPercent = cpuDelta / sysDelta * online * 100.0
+	}
+
+	// Page cache is reclaimable, so leave it out like the docker CLI does
+	cs.memUsage = s.MemoryStats.Usage
+	cache, ok := s.MemoryStats.Stats["inactive_file"] // cgroup v2
+	if !ok {
+		cache = s.MemoryStats.Stats["total_inactive_file"] // cgroup v1
+	}
+	if cache < cs.memUsage {
+		cs.memUsage -= cache
+	}
+
+	cs.read = s.Read
+	for _, n := range s.Networks {
+		cs.hasNet = true
+		cs.rxBytes += n.RxBytes
+		cs.txBytes += n.TxBytes
+	}
+	// Counters start over when the container restarts
+	if secs := cs.read.Sub(prev.read).Seconds(); cs.hasNet && prev.hasNet && !prev.read.IsZero() && secs > 0 &&
+		cs.rxBytes >= prev.rxBytes && cs.txBytes >= prev.txBytes {
+		cs.rxRate = float64(cs.rxBytes-prev.rxBytes) / secs
+		cs.txRate = float64(cs.txBytes-prev.txBytes) / secs
+		cs.netRated = true
+	}
+
+	cs.readBytes, cs.writeBytes, cs.hasBlkio = blkioTotals(s)
+	if secs := cs.read.Sub(prev.read).Seconds(); cs.hasBlkio && prev.hasBlkio && !prev.read.IsZero() && secs > 0 &&
+		cs.readBytes >= prev.readBytes && cs.writeBytes >= prev.writeBytes {
+		cs.readRate = float64(cs.readBytes-prev.readBytes) / secs
+		cs.writeRate = float64(cs.writeBytes-prev.writeBytes) / secs
+		cs.blkioRated = true
+	}
+	return cs
+}
+
+// blkioTotals sums the bytes read and written across block devices.
+// cgroup v1 names the ops "Read"/"Write" and v2 "read"/"write"; Windows
+// reports storage stats instead. ok is false when neither is reported,
+// as on some cgroup v2 hosts without the io controller.
+func blkioTotals(s container.StatsResponse) (read, write uint64, ok bool) {
+	entries := s.BlkioStats.IoServiceBytesRecursive
+	for _, e := range entries {
+		switch strings.ToLower(e.Op) {
+		case "read":
+			read += e.Value
+		case "write":
+			write += e.Value
+		}
+	}
+	if len(entries) > 0 {
+		return read, write, true
+	}
+	if st := s.StorageStats; st.ReadSizeBytes > 0 || st.WriteSizeBytes > 0 {
+		return st.ReadSizeBytes, st.WriteSizeBytes, true
+	}
+	return 0, 0, false
+}
+
+// visibleRunningContainerIDs returns the IDs of the running containers in
+// the rows the containers table shows on screen, so sampling cost follows
+// the screen rather than the host's container count.
+func (m model) visibleRunningContainerIDs() []string {
+	keys := m.rowKeys[0]
+	start, end := m.visibleRows(0)
+	start, end = min(start, len(keys)), min(end, len(keys))
+	var ids []string
+	for _, key := range keys[start:end] {
+		if i, ok := m.index[0][key]; ok && i < len(m.containers) && m.containers[i].State == "running" {
+			ids = append(ids, key)
+		}
+	}
+	return ids
+}
+
+// sortedContainers returns the loaded containers ordered by the active stats
+// sort, busiest first. Containers without stats keep their relative order.
+func (m model) sortedContainers() []container.Summary {
+	cs := make([]container.Summary, len(m.containers))
+	copy(cs, m.containers)
+	if m.sortBy == sortNone {
+		return cs
+	}
+	sort.SliceStable(cs, func(i, j int) bool {
+		a, b := m.stats[cs[i].ID], m.stats[cs[j].ID]
+		if m.sortBy == sortMem {
+			return a.memUsage > b.memUsage
+		}
+		return a.cpuPercent > b.cpuPercent
+	})
+	return cs
+}
+
+// topConsumer returns the ID of the container using the most of the active
+// sort resource (CPU when unsorted), or "" when nothing is using any.
+func (m model) topConsumer() string {
+	top := ""
+	var best float64
+	for id, s := range m.stats {
+		v := s.cpuPercent
+		if m.sortBy == sortMem {
+			v = float64(s.memUsage)
+		}
+		if v > best {
+			best, top = v, id
+		}
+	}
+	return top
+}
+
+// formatCPU renders a CPU percentage cell, or "-" when not sampled.
+func formatCPU(s containerStats, ok bool) string {
+	if !ok {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", s.cpuPercent)
+}
+
+// formatNetIO renders the receive and transmit rates, e.g.
+// "↓1.2MB/s ↑340.0KB/s", or "-" when not sampled, not yet rated or the
+// container has no network of its own.
+func formatNetIO(s containerStats, ok bool) string {
+	if !ok || !s.hasNet || !s.netRated {
+		return "-"
+	}
+	return fmt.Sprintf("↓%s/s ↑%s/s", format.HumanizeBytes(int64(s.rxRate)), format.HumanizeBytes(int64(s.txRate)))
+}
+
+// formatBlockIO renders the disk read and write rates, e.g.
+// "read 5.0MB/s write 1.0MB/s", or "-" when not sampled or not yet rated,
+// saying so when the platform reports no block I/O.
+func formatBlockIO(s containerStats, ok bool) string {
+	switch {
+	case ok && !s.hasBlkio:
+		return "- (not reported on this platform)"
+	case !ok || !s.blkioRated:
+		return "-"
+	}
+	return fmt.Sprintf("read %s/s write %s/s", format.HumanizeBytes(int64(s.readRate)), format.HumanizeBytes(int64(s.writeRate)))
+}
+
+// formatMem renders a memory usage cell, or "-" when not sampled.
+func formatMem(s containerStats, ok bool) string {
+	if !ok {
+		return "-"
+	}
+	return format.HumanizeBytes(int64(s.memUsage))
+}
@@ -0,0 +1,28 @@
+package ui
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// monochrome reports whether output is rendered without color, because the
+// terminal has none or NO_COLOR is set. lipgloss then drops every color, so
+// emphasis has to come from text attributes instead.
+func monochrome() bool {
+	return lipgloss.ColorProfile() == termenv.Ascii
+}
+
+// useMonochromeStyles replaces color-only emphasis in the shared styles
+// with bold, underline and reverse video.
+func useMonochromeStyles() {
+	titleStyle = titleStyle.Underline(true)
+	compactTitleStyle = titleStyle.Padding(0)
+	promptStyle = promptStyle.Bold(true)
+	confirmStyle = confirmStyle.Underline(true)
+	columnCursorStyle = lipgloss.NewStyle().Reverse(true)
+	remoteBannerStyle = remoteBannerStyle.Reverse(true)
+	readOnlyBannerStyle = readOnlyBannerStyle.Reverse(true)
+	disabledHelpStyle = disabledHelpStyle.Strikethrough(true)
+	logMatchStyle = lipgloss.NewStyle().Underline(true)
+	logCurrentMatchStyle = lipgloss.NewStyle().Reverse(true)
+}
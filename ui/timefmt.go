@@ -0,0 +1,109 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Antityping/superdocker/format"
+)
+
+// timeFormat selects how Created/Finished timestamps are shown.
+type timeFormat int
+
+const (
+	timeRelative timeFormat = iota // "3h ago"
+	timeAbsolute                   // "2006-01-02 15:04:05 UTC"
+	timeISO                        // RFC 3339 in UTC
+)
+
+var timeFormatNames = [...]string{"relative", "absolute", "iso"}
+
+func (f timeFormat) String() string {
+	return timeFormatNames[f]
+}
+
+// parseTimeFormat resolves a time format name from the preferences file;
+// "" selects the default relative format.
+func parseTimeFormat(s string) (timeFormat, error) {
+	if s == "" {
+		return timeRelative, nil
+	}
+	for i, name := range timeFormatNames {
+		if s == name {
+			return timeFormat(i), nil
+		}
+	}
+	return timeRelative, fmt.Errorf("unknown time format %q (want relative, absolute or iso)", s)
+}
+
+// format renders t, or "-" for the zero time.
+func (f timeFormat) format(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	switch f {
+	case timeAbsolute:
+		return t.UTC().Format("2006-01-02 15:04:05 UTC")
+	case timeISO:
+		return t.UTC().Format(time.RFC3339)
+	default:
+		return agoString(time.Since(t))
+	}
+}
+
+// formatUnix renders a Unix timestamp in seconds, as list endpoints report.
+func (f timeFormat) formatUnix(sec int64) string {
+	if sec <= 0 {
+		return "-"
+	}
+	return f.format(time.Unix(sec, 0))
+}
+
+// formatString renders an RFC 3339 timestamp as inspect endpoints report,
+// passing through anything it cannot parse.
+func (f timeFormat) formatString(s string) string {
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return format.OrDash(s)
+	}
+	return f.format(t)
+}
+
+// agoString renders a duration in its largest whole unit, e.g. "3h ago".
+func agoString(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	}
+}
+
+// uptimeString renders how long a container has been running from its
+// inspect StartedAt, e.g. "Up 3h12m", or "-" when it is not running.
+func uptimeString(running bool, startedAt string) string {
+	t, err := time.Parse(time.RFC3339Nano, startedAt)
+	if !running || err != nil || t.IsZero() {
+		return "-"
+	}
+	return "Up " + shortDuration(time.Since(t))
+}
+
+// shortDuration renders d in its two largest units, e.g. "3h12m" or "2d4h".
+func shortDuration(d time.Duration) string {
+	d = max(d, 0).Truncate(time.Second)
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm%ds", int(d.Minutes()), int(d.Seconds())%60)
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh%dm", int(d.Hours()), int(d.Minutes())%60)
+	default:
+		return fmt.Sprintf("%dd%dh", int(d.Hours()/24), int(d.Hours())%24)
+	}
+}
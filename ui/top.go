@@ -0,0 +1,100 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Antityping/superdocker/docker"
+	"github.com/Antityping/superdocker/format"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// topInterval is how often the processes view is refreshed.
+const topInterval = 2 * time.Second
+
+// topTickMsg asks for a fresh process list of the container with id.
+type topTickMsg struct {
+	id string
+}
+
+func topTick(id string) tea.Cmd {
+	return tea.Tick(topInterval, func(time.Time) tea.Msg { return topTickMsg{id: id} })
+}
+
+// refreshTop reloads the open processes view.
+func (m model) refreshTop() tea.Cmd {
+	name := format.Short12(m.topID)
+	if i, ok := m.index[0][m.topID]; ok && i < len(m.containers) {
+		name = containerName(m.containers[i])
+	}
+	return m.backend.containerTop(m.topID, name)
+}
+
+// openTop shows the processes of the selected container, like `docker top`.
+// Stopped containers have none, so the API is not called for them.
+func (m model) openTop() (tea.Model, tea.Cmd) {
+	c := m.selectedContainer()
+	if c == nil {
+		m.status = "No container selected."
+		return m, nil
+	}
+	name := containerName(*c)
+	if c.State != "running" {
+		m.mode = viewDetail
+		m.topID = ""
+		m.detailTitle = "Processes " + name
+		m.detail = viewport.New(m.width, max(m.height-4, 5))
+		m.setDetailBody("  container not running\n")
+		return m, nil
+	}
+	next, cmd := m.loadDetail(m.backend.containerTop(c.ID, name))
+	nm := next.(model)
+	nm.topID = c.ID
+	return nm, cmd
+}
+
+// containerTop lists the processes running in a container.
+func (b *backend) containerTop(id, name string) tea.Cmd {
+	return b.run(func(ctx context.Context, cli docker.Client) tea.Msg {
+		start := time.Now()
+		top, err := cli.ContainerTop(ctx, id, nil)
+		logCall("ContainerTop", start, err, "id", format.Short12(id))
+		if err != nil {
+			return detailLoadedMsg{err: err}
+		}
+		title := fmt.Sprintf("Processes %s (%d, every %s)", name, len(top.Processes), topInterval)
+		return detailLoadedMsg{title: title, body: renderTop(top.Titles, top.Processes)}
+	})
+}
+
+// renderTop lays out the process table in aligned columns. The last
+// column, the command, is left unpadded.
+func renderTop(titles []string, procs [][]string) string {
+	widths := make([]int, len(titles))
+	for i, t := range titles {
+		widths[i] = len(t)
+	}
+	for _, p := range procs {
+		for i := 0; i < len(p) && i < len(widths); i++ {
+			widths[i] = max(widths[i], len(p[i]))
+		}
+	}
+	row := func(cells []string) string {
+		parts := make([]string, len(cells))
+		for i, c := range cells {
+			if i < len(cells)-1 && i < len(widths) {
+				c = fmt.Sprintf("%-*s", widths[i], c)
+			}
+			parts[i] = c
+		}
+		return "  " + strings.Join(parts, "  ")
+	}
+	lines := []string{titleStyle.Render(strings.TrimSpace(row(titles)))}
+	for _, p := range procs {
+		lines = append(lines, row(p))
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
@@ -0,0 +1,161 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Antityping/superdocker/docker"
+	"github.com/Antityping/superdocker/format"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-units"
+)
+
+// restartPolicies are the policies offered by the restart policy menu.
+var restartPolicies = []struct {
+	key  string
+	name container.RestartPolicyMode
+}{
+	{"n", container.RestartPolicyDisabled},
+	{"o", container.RestartPolicyOnFailure},
+	{"a", container.RestartPolicyAlways},
+	{"u", container.RestartPolicyUnlessStopped},
+}
+
+// confirmRestartPolicy shows the selected container's restart policy and
+// offers the others to switch to.
+func (m model) confirmRestartPolicy() (tea.Model, tea.Cmd) {
+	c := m.selectedContainer()
+	if c == nil {
+		m.status = "No container selected."
+		return m, nil
+	}
+	ci, ok := m.inspected[c.ID]
+	if !ok || ci.ContainerJSONBase == nil || ci.HostConfig == nil {
+		m.status = "Container details are still loading."
+		return m, nil
+	}
+	current := ci.HostConfig.RestartPolicy.Name
+	if current == "" {
+		current = container.RestartPolicyDisabled
+	}
+	name := containerName(*c)
+	var choices []confirmChoice
+	for _, p := range restartPolicies {
+		if p.name == current {
+			continue
+		}
+		choices = append(choices, confirmChoice{
+			key:    p.key,
+			label:  string(p.name),
+			status: fmt.Sprintf("Setting restart policy of %s to %s...", name, p.name),
+			id:     c.ID,
+			cmd:    m.backend.setRestartPolicy(c.ID, p.name),
+		})
+	}
+	return m.askPick(fmt.Sprintf("Restart policy of %s is %s. Change to:", name, current), choices...)
+}
+
+// setRestartPolicy changes a container's restart policy in place.
+func (b *backend) setRestartPolicy(id string, policy container.RestartPolicyMode) tea.Cmd {
+	return b.run(func(ctx context.Context, cli docker.Client) tea.Msg {
+		done := actionDoneMsg{id: id, action: "Restart policy update", reload: resContainers}
+		start := time.Now()
+		_, err := cli.ContainerUpdate(ctx, id, container.UpdateConfig{
+			RestartPolicy: container.RestartPolicy{Name: policy},
+		})
+		logCall("ContainerUpdate", start, err, "id", format.Short12(id), "restart", policy)
+		if err != nil {
+			done.err = err
+			return done
+		}
+		done.status = fmt.Sprintf("Restart policy set to %s.", policy)
+		return done
+	})
+}
+
+// openLimitsPrompt shows the selected container's CPU and memory limits and
+// prompts for new ones.
+func (m model) openLimitsPrompt() (tea.Model, tea.Cmd) {
+	c := m.selectedContainer()
+	if c == nil {
+		m.status = "No container selected."
+		return m, nil
+	}
+	ci, ok := m.inspected[c.ID]
+	if !ok || ci.ContainerJSONBase == nil || ci.HostConfig == nil {
+		m.status = "Container details are still loading."
+		return m, nil
+	}
+	m.limitsID = c.ID
+	hc := ci.HostConfig
+	label := fmt.Sprintf("Limits for %s (now %s CPUs, %s memory): ", containerName(*c), cpuString(hc.NanoCPUs), limitString(hc.Memory))
+	return m.openPrompt(promptLimits, label, formatLimits(hc.NanoCPUs, hc.Memory))
+}
+
+// formatLimits renders limits in the form parseLimits reads, leaving out
+// unset ones.
+func formatLimits(nanoCPUs, memory int64) string {
+	var parts []string
+	if nanoCPUs > 0 {
+		parts = append(parts, "cpus="+strconv.FormatFloat(float64(nanoCPUs)/1e9, 'f', -1, 64))
+	}
+	if memory > 0 {
+		parts = append(parts, "memory="+units.BytesSize(float64(memory)))
+	}
+	return strings.Join(parts, " ")
+}
+
+// parseLimits reads "cpus=1.5 memory=512m". Either may be left out to keep
+// the current value; a zero in the result means unchanged.
+func parseLimits(s string) (container.Resources, error) {
+	var r container.Resources
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return r, errors.New("enter cpus=<n> and/or memory=<size>, e.g. cpus=1.5 memory=512m")
+	}
+	for _, f := range fields {
+		k, v, ok := strings.Cut(f, "=")
+		if !ok {
+			return r, fmt.Errorf("%q: expected key=value", f)
+		}
+		switch k {
+		case "cpus":
+			n, err := strconv.ParseFloat(v, 64)
+			if err != nil || n <= 0 {
+				return r, fmt.Errorf("cpus: %q is not a positive number", v)
+			}
+			r.NanoCPUs = int64(n * 1e9)
+		case "memory", "mem":
+			n, err := units.RAMInBytes(v)
+			if err != nil || n <= 0 {
+				return r, fmt.Errorf("memory: %q is not a size like 512m or 1.5g", v)
+			}
+			r.Memory = n
+		default:
+			return r, fmt.Errorf("unknown limit %q; use cpus or memory", k)
+		}
+	}
+	return r, nil
+}
+
+// setLimits applies new CPU and memory limits to a running container
+// without restarting it.
+func (b *backend) setLimits(id string, r container.Resources) tea.Cmd {
+	return b.run(func(ctx context.Context, cli docker.Client) tea.Msg {
+		done := actionDoneMsg{id: id, action: "Limits update", reload: resContainers}
+		start := time.Now()
+		_, err := cli.ContainerUpdate(ctx, id, container.UpdateConfig{Resources: r})
+		logCall("ContainerUpdate", start, err, "id", format.Short12(id), "nano_cpus", r.NanoCPUs, "memory", r.Memory)
+		if err != nil {
+			done.err = err
+			return done
+		}
+		done.status = "Limits updated."
+		return done
+	})
+}
@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strings"
+
+	"github.com/Antityping/superdocker/format"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Build information, injected at build time:
+//
+//	pkg=github.com/Antityping/superdocker/ui
+//	go build -ldflags "-X $pkg.version=v1.2.3 -X $pkg.commit=$(git rev-parse --short HEAD) -X $pkg.date=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+var (
+	version = "dev"
+	commit  = ""
+	date    = ""
+)
+
+// buildInfo returns the version, commit and build date, falling back to the
+// module and VCS info recorded by `go install`/`go build` when no ldflags
+// were given.
+func buildInfo() (string, string, string) {
+	v, c, d := version, commit, date
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		if v == "dev" && bi.Main.Version != "" && bi.Main.Version != "(devel)" {
+			v = bi.Main.Version
+		}
+		for _, s := range bi.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				if c == "" {
+					c = format.Short12(s.Value)
+				}
+			case "vcs.time":
+				if d == "" {
+					d = s.Value
+				}
+			}
+		}
+	}
+	if c == "" {
+		c = "unknown"
+	}
+	if d == "" {
+		d = "unknown"
+	}
+	return v, c, d
+}
+
+// VersionString is the one-line output of --version.
+func VersionString() string {
+	v, c, d := buildInfo()
+	return fmt.Sprintf("superdocker %s (commit %s, built %s, %s %s/%s)", v, c, d, runtime.Version(), runtime.GOOS, runtime.GOARCH)
+}
+
+// aboutView renders the about overlay with build info and key bindings.
+func (m model) aboutView() string {
+	v, c, d := buildInfo()
+	lines := []string{
+		titleStyle.Render("superdocker"),
+		"",
+		fmt.Sprintf("Version: %s", v),
+		fmt.Sprintf("Commit:  %s", c),
+		fmt.Sprintf("Built:   %s", d),
+		fmt.Sprintf("Go:      %s %s/%s", runtime.Version(), runtime.GOOS, runtime.GOARCH),
+		"",
+		"Press any key to close.",
+	}
+	box := baseStyle.Padding(0, 2).Render(strings.Join(lines, "\n"))
+	if m.width > 0 && m.height > 0 {
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+	}
+	return "\n" + box + "\n"
+}
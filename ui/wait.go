@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Antityping/superdocker/docker"
+	"github.com/Antityping/superdocker/format"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/docker/docker/api/types/container"
+)
+
+// containerWait is an in-flight wait for a container to exit.
+type containerWait struct {
+	id     string
+	name   string
+	cancel context.CancelFunc
+}
+
+type waitDoneMsg struct {
+	id   string
+	code int64
+	err  error
+}
+
+// startWait waits in the background for the selected container to exit.
+// The dashboard stays interactive and esc abandons the wait.
+func (m model) startWait() (tea.Model, tea.Cmd) {
+	c := m.selectedContainer()
+	if c == nil {
+		m.status = "No container selected."
+		return m, nil
+	}
+	if m.waiting != nil {
+		m.waiting.cancel()
+	}
+	ctx, cancel := context.WithCancel(m.backend.ctx)
+	name := containerName(*c)
+	m.waiting = &containerWait{id: c.ID, name: name, cancel: cancel}
+	m.status = fmt.Sprintf("Waiting for %s to exit... (esc: stop waiting)", name)
+	return m, m.backend.waitContainer(ctx, c.ID)
+}
+
+// stopWaiting abandons the current wait without touching the container.
+func (m model) stopWaiting() (tea.Model, tea.Cmd) {
+	m.waiting.cancel()
+	m.status = fmt.Sprintf("Stopped waiting for %s.", m.waiting.name)
+	m.waiting = nil
+	return m, nil
+}
+
+// waitContainer blocks until the container is no longer running or ctx is
+// canceled, and reports its exit code.
+func (b *backend) waitContainer(ctx context.Context, id string) tea.Cmd {
+	return b.run(func(_ context.Context, cli docker.Client) tea.Msg {
+		start := time.Now()
+		statusCh, errCh := cli.ContainerWait(ctx, id, container.WaitConditionNotRunning)
+		select {
+		case st := <-statusCh:
+			logCall("ContainerWait", start, nil, "id", format.Short12(id), "exit", st.StatusCode)
+			if st.Error != nil {
+				return waitDoneMsg{id: id, err: fmt.Errorf("%s", st.Error.Message)}
+			}
+			return waitDoneMsg{id: id, code: st.StatusCode}
+		case err := <-errCh:
+			logCall("ContainerWait", start, err, "id", format.Short12(id))
+			return waitDoneMsg{id: id, err: err}
+		}
+	})
+}
@@ -0,0 +1,140 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Antityping/superdocker/docker"
+	"github.com/Antityping/superdocker/format"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/docker/docker/api/types/container"
+)
+
+const (
+	// watchInterval is how often a watched container's state is polled;
+	// much faster than the auto-refresh, to catch short crash loops.
+	watchInterval = 500 * time.Millisecond
+	// watchEvents caps the transitions the watch keeps.
+	watchEvents = 20
+)
+
+// containerWatch polls one container's state and records its transitions.
+type containerWatch struct {
+	id       string
+	name     string
+	state    string // last polled state, "" before the first poll
+	started  string // StartedAt of the last poll, to spot restarts in between
+	restarts int
+	events   []string
+}
+
+// watchTickMsg asks for the next poll of watch. Ticks of a stopped watch
+// are ignored, so the loop ends with it.
+type watchTickMsg struct {
+	watch *containerWatch
+}
+
+type watchPolledMsg struct {
+	watch *containerWatch
+	at    time.Time
+	info  container.InspectResponse
+	err   error
+}
+
+func watchTick(w *containerWatch) tea.Cmd {
+	return tea.Tick(watchInterval, func(time.Time) tea.Msg { return watchTickMsg{watch: w} })
+}
+
+// toggleWatch starts watching the selected container, or stops the watch
+// running on it.
+func (m model) toggleWatch() (tea.Model, tea.Cmd) {
+	c := m.selectedContainer()
+	if c == nil {
+		m.status = "No container selected."
+		return m, nil
+	}
+	if m.watch != nil && m.watch.id == c.ID {
+		return m.stopWatch(), nil
+	}
+	m.watch = &containerWatch{id: c.ID, name: containerName(*c)}
+	m.status = fmt.Sprintf("Watching %s every %s (W: stop).", m.watch.name, watchInterval)
+	return m, m.backend.pollWatch(m.watch)
+}
+
+// stopWatch ends the current watch; its pending tick is then dropped.
+func (m model) stopWatch() model {
+	m.status = fmt.Sprintf("Stopped watching %s.", m.watch.name)
+	m.watch = nil
+	return m
+}
+
+// pollWatch inspects the watched container once.
+func (b *backend) pollWatch(w *containerWatch) tea.Cmd {
+	return b.run(func(ctx context.Context, cli docker.Client) tea.Msg {
+		start := time.Now()
+		info, err := cli.ContainerInspect(ctx, w.id)
+		logCall("ContainerInspect", start, err, "id", format.Short12(w.id), "watch", true)
+		return watchPolledMsg{watch: w, at: start, info: info, err: err}
+	})
+}
+
+// recordPoll notes the transitions since the previous poll, reporting
+// whether there were any. A changed start time or restart count with an
+// unchanged state means the container went down and came back in between.
+func (w *containerWatch) recordPoll(at time.Time, st *container.State, restarts int) bool {
+	first := w.state == ""
+	prev := w.state
+	restarted := !first && (st.StartedAt != w.started || restarts != w.restarts)
+	w.state, w.started, w.restarts = st.Status, st.StartedAt, restarts
+	var event string
+	switch {
+	case first:
+		event = st.Status
+	case prev != st.Status:
+		event = prev + " → " + st.Status
+	case restarted:
+		event = "restarted"
+	default:
+		return false
+	}
+	if st.Status == "exited" || st.Status == "dead" {
+		event += fmt.Sprintf(" (exit code %d)", st.ExitCode)
+		if st.OOMKilled {
+			event += ", OOM killed"
+		}
+	}
+	w.events = append(w.events, at.Format("15:04:05.000")+"  "+event)
+	if over := len(w.events) - watchEvents; over > 0 {
+		w.events = w.events[over:]
+	}
+	return !first
+}
+
+// applyWatchPoll records a poll of the current watch, keeping the inspect
+// cache fresh with it, and tells whether the containers need a reload.
+func (m *model) applyWatchPoll(msg watchPolledMsg) bool {
+	info := msg.info
+	if info.ContainerJSONBase == nil || info.State == nil {
+		return false
+	}
+	m.inspected[msg.watch.id] = info
+	return msg.watch.recordPoll(msg.at, info.State, info.RestartCount)
+}
+
+// renderWatch lists the recorded transitions of the watched container
+// with id, newest first, or returns "" when it isn't watched.
+func (m model) renderWatch(id string) string {
+	if m.watch == nil || m.watch.id != id {
+		return ""
+	}
+	if len(m.watch.events) == 0 {
+		return "polling..."
+	}
+	lines := make([]string, len(m.watch.events))
+	for i, e := range m.watch.events {
+		lines[len(lines)-1-i] = e
+	}
+	return strings.Join(lines, "\n")
+}